@@ -0,0 +1,313 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// Write approval policy for incoming LPP and LPC limits
+//
+// Every incoming limit is checked against the policy of its use case. Limits passing
+// all rules are approved, or with manual approval enabled published to
+// eebus2mqtt/hems/<usecase>/approval/request and decided on
+// eebus2mqtt/hems/<usecase>/approval/set. Every decision is published to
+// eebus2mqtt/hems/<usecase>/approval/result.
+const (
+	approvalCommand = "approval"
+
+	// SPINE denies pending writes after this timeout, see spine.FeatureLocal
+	spineWriteApprovalTimeout = 10 * time.Second
+)
+
+// check a limit against the rules of a policy
+//
+// value is the limit as positive W, returns the reason if the limit is denied
+func evaluatePolicy(p hemsconfig.ApprovalPolicy, ski string, write ucapi.LoadLimit, value float64) (bool, string) {
+	if !p.AllowsSKI(ski) {
+		return false, "SKI not allowed"
+	}
+
+	// the value and duration of an inactive limit do not apply
+	if !write.IsActive {
+		return true, ""
+	}
+
+	maxDuration := time.Duration(p.MaxDuration) * time.Second
+
+	switch {
+	case write.Duration == 0 && !p.AllowZeroDuration:
+		return false, "Duration zero"
+	case p.MinValue != nil && value < *p.MinValue:
+		return false, fmt.Sprintf("Value below %.f W", *p.MinValue)
+	case p.MaxValue != nil && value > *p.MaxValue:
+		return false, fmt.Sprintf("Value above %.f W", *p.MaxValue)
+	case maxDuration > 0 && write.Duration > maxDuration:
+		return false, fmt.Sprintf("Duration above %d s", p.MaxDuration)
+	}
+
+	return true, ""
+}
+
+// manual approval request published via MQTT
+type approvalRequest struct {
+	Id       uint64  `json:"id"`
+	Ski      string  `json:"ski"`
+	Value    float64 `json:"value"`
+	Duration float64 `json:"duration"`
+	Active   bool    `json:"active"`
+	Timeout  float64 `json:"timeout"`
+}
+
+// decision published for every incoming limit
+type approvalResult struct {
+	Id       uint64 `json:"id"`
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason,omitempty"`
+	Manual   bool   `json:"manual"`
+}
+
+// manual decision received via MQTT, without id all pending limits are decided
+type approvalDecision struct {
+	Id      *uint64 `json:"id,omitempty"`
+	Approve bool    `json:"approve"`
+	Reason  string  `json:"reason,omitempty"`
+}
+
+// approves or denies incoming limits of one use case
+type limitApprover struct {
+	usecase string // lpp or lpc
+
+	// return the policy of the use case
+	policy func() hemsconfig.ApprovalPolicy
+	// return the limit as positive W, or the reason why the sign is invalid
+	value func(value float64) (float64, string)
+	// send the decision to the remote
+	decide func(msgCounter model.MsgCounterType, approve bool, reason string)
+
+	pending map[model.MsgCounterType]*time.Timer
+	mux     sync.Mutex
+}
+
+func newLimitApprover(
+	usecase string,
+	policy func() hemsconfig.ApprovalPolicy,
+	value func(value float64) (float64, string),
+	decide func(msgCounter model.MsgCounterType, approve bool, reason string),
+) *limitApprover {
+	return &limitApprover{
+		usecase: usecase,
+		policy:  policy,
+		value:   value,
+		decide:  decide,
+		pending: make(map[model.MsgCounterType]*time.Timer),
+	}
+}
+
+// production limits are negative
+func productionValue(value float64) (float64, string) {
+	if value > 0 {
+		return 0, "Value > 0"
+	}
+	return -value, ""
+}
+
+// consumption limits are positive
+func consumptionValue(value float64) (float64, string) {
+	if value < 0 {
+		return 0, "Value < 0"
+	}
+	return value, ""
+}
+
+// handle the pending limits of a WriteApprovalRequired event
+func (a *limitApprover) handle(ski string, pendingWrites map[model.MsgCounterType]ucapi.LoadLimit) {
+	for msgCounter, write := range pendingWrites {
+		a.mux.Lock()
+		_, waiting := a.pending[msgCounter]
+		a.mux.Unlock()
+		if waiting {
+			continue
+		}
+
+		usecaseLog().Info("limit write requires approval", "usecase", a.usecase, "msg_counter", msgCounter, "limit", write.Value, "duration", write.Duration, "active", write.IsActive)
+
+		policy := a.policy()
+
+		value, reason := a.value(write.Value)
+		approve := reason == ""
+		if approve {
+			approve, reason = evaluatePolicy(policy, ski, write, value)
+		}
+
+		switch {
+		case !approve:
+			a.finish(msgCounter, false, reason, a.policyReason(reason), false)
+		case policy.Manual:
+			a.requestManual(msgCounter, ski, write, value, policy.ManualTimeoutDuration())
+		default:
+			a.finish(msgCounter, true, "", "", false)
+		}
+	}
+}
+
+// publish a manual approval request and deny it if no decision arrives in time
+func (a *limitApprover) requestManual(msgCounter model.MsgCounterType, ski string, write ucapi.LoadLimit, value float64, timeout time.Duration) {
+	a.mux.Lock()
+	a.pending[msgCounter] = time.AfterFunc(timeout, func() {
+		if a.take(msgCounter) {
+			reason := "Manual approval timeout"
+			a.finish(msgCounter, false, reason, a.policyReason(reason), true)
+		}
+	})
+	a.mux.Unlock()
+
+	request := approvalRequest{
+		Id:       uint64(msgCounter),
+		Ski:      ski,
+		Value:    value,
+		Duration: write.Duration.Seconds(),
+		Active:   write.IsActive,
+		Timeout:  timeout.Seconds(),
+	}
+	payload, _ := json.Marshal(request)
+	client.Publish(topicPrefix+a.usecase+"/"+approvalCommand+"/request", qos, false, payload)
+	auditEvent("limit waiting for manual approval", "usecase", a.usecase, "msg_counter", msgCounter)
+}
+
+// remove a pending manual approval, returns false if it was already decided
+func (a *limitApprover) take(msgCounter model.MsgCounterType) bool {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	timer, ok := a.pending[msgCounter]
+	if !ok {
+		return false
+	}
+	timer.Stop()
+	delete(a.pending, msgCounter)
+	return true
+}
+
+// return the reason sent to the remote for a denial by the policy
+//
+// the reason of the policy replaces the rule, manual denials keep their reason
+func (a *limitApprover) policyReason(reason string) string {
+	if override := a.policy().Reason; override != "" {
+		return override
+	}
+	return reason
+}
+
+// send the decision to the remote, log and publish it
+//
+// sentReason is sent to the remote, reason is logged and published
+func (a *limitApprover) finish(msgCounter model.MsgCounterType, approve bool, reason, sentReason string, manual bool) {
+	a.decide(msgCounter, approve, sentReason)
+
+	if !approve {
+		auditEvent("limit denied", "usecase", a.usecase, "msg_counter", msgCounter, "reason", reason)
+	}
+
+	result := approvalResult{
+		Id:       uint64(msgCounter),
+		Approved: approve,
+		Reason:   reason,
+		Manual:   manual,
+	}
+	payload, _ := json.Marshal(result)
+	client.Publish(topicPrefix+a.usecase+"/"+approvalCommand+"/result", qos, false, payload)
+}
+
+// decide pending manual approvals
+//
+// the payload is "approve", "deny" or a JSON approvalDecision,
+// returns the number of decided limits
+func (a *limitApprover) decideManual(payload []byte) (int, error) {
+	var decision approvalDecision
+
+	switch text := strings.ToLower(strings.TrimSpace(string(payload))); text {
+	case "approve":
+		decision.Approve = true
+	case "deny":
+		decision.Reason = "Denied manually"
+	default:
+		if err := json.Unmarshal(payload, &decision); err != nil {
+			return 0, errors.New(`invalid payload: "approve", "deny" or a JSON decision is required`)
+		}
+		if !decision.Approve && decision.Reason == "" {
+			decision.Reason = "Denied manually"
+		}
+	}
+
+	var msgCounters []model.MsgCounterType
+	a.mux.Lock()
+	for msgCounter := range a.pending {
+		if decision.Id == nil || uint64(msgCounter) == *decision.Id {
+			msgCounters = append(msgCounters, msgCounter)
+		}
+	}
+	a.mux.Unlock()
+
+	decided := 0
+	for _, msgCounter := range msgCounters {
+		if a.take(msgCounter) {
+			a.finish(msgCounter, decision.Approve, decision.Reason, decision.Reason, true)
+			decided++
+		}
+	}
+
+	if decided == 0 {
+		return 0, errors.New("no pending approval")
+	}
+	return decided, nil
+}
+
+// handle manual decisions on eebus2mqtt/hems/<usecase>/approval/set
+func (h *hems) onApprovalMessage(client mqtt.Client, usecase string, payload []byte) {
+	name := usecase + "/" + approvalCommand
+	ack := commandAck{Command: name}
+
+	var approver *limitApprover
+	switch usecase {
+	case "lpp":
+		approver = h.lppApprover
+	case "lpc":
+		approver = h.lpcApprover
+	}
+
+	if approver == nil {
+		ack.Error = errNotReady.Error()
+	} else if decided, err := approver.decideManual(payload); err != nil {
+		ack.Error = err.Error()
+	} else {
+		value := float64(decided)
+		ack.Success = true
+		ack.Value = &value
+	}
+
+	ackPayload, _ := json.Marshal(ack)
+	client.Publish(topicPrefix+name+ackTopicSuffix, qos, false, ackPayload)
+}
+
+// return the SPINE write approval timeout needed for the configured manual approvals
+func writeApprovalTimeout() time.Duration {
+	timeout := spineWriteApprovalTimeout
+	cfg := hemsConfig()
+
+	for _, policy := range []hemsconfig.ApprovalPolicy{cfg.LPPApproval, cfg.LPCApproval} {
+		if policy.Manual && policy.ManualTimeoutDuration()+2*time.Second > timeout {
+			timeout = policy.ManualTimeoutDuration() + 2*time.Second
+		}
+	}
+
+	return timeout
+}
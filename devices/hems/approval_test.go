@@ -0,0 +1,246 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/enbility/spine-go/model"
+)
+
+func (s *HemsSuite) Test_EvaluatePolicy() {
+	minValue, maxValue := 1000.0, 5000.0
+	otherSKI := "fedcba9876543210fedcba9876543210fedcba98"
+
+	tests := []struct {
+		name    string
+		policy  hemsconfig.ApprovalPolicy
+		write   ucapi.LoadLimit
+		approve bool
+		reason  string
+	}{
+		{
+			name:    "no rules",
+			write:   ucapi.LoadLimit{Value: 3000, Duration: time.Hour, IsActive: true},
+			approve: true,
+		},
+		{
+			name:    "inactive limit",
+			policy:  hemsconfig.ApprovalPolicy{MinValue: &minValue},
+			write:   ucapi.LoadLimit{Value: 0},
+			approve: true,
+		},
+		{
+			name:   "below min value",
+			policy: hemsconfig.ApprovalPolicy{MinValue: &minValue},
+			write:  ucapi.LoadLimit{Value: 500, Duration: time.Hour, IsActive: true},
+			reason: "Value below 1000 W",
+		},
+		{
+			name:    "at min value",
+			policy:  hemsconfig.ApprovalPolicy{MinValue: &minValue},
+			write:   ucapi.LoadLimit{Value: 1000, Duration: time.Hour, IsActive: true},
+			approve: true,
+		},
+		{
+			name:   "above max value",
+			policy: hemsconfig.ApprovalPolicy{MaxValue: &maxValue},
+			write:  ucapi.LoadLimit{Value: 6000, Duration: time.Hour, IsActive: true},
+			reason: "Value above 5000 W",
+		},
+		{
+			name:    "allowed SKI",
+			policy:  hemsconfig.ApprovalPolicy{AllowedSKIs: []string{testSKI}},
+			write:   ucapi.LoadLimit{Value: 3000, Duration: time.Hour, IsActive: true},
+			approve: true,
+		},
+		{
+			name:   "SKI not allowed",
+			policy: hemsconfig.ApprovalPolicy{AllowedSKIs: []string{otherSKI}},
+			write:  ucapi.LoadLimit{Value: 3000, Duration: time.Hour, IsActive: true},
+			reason: "SKI not allowed",
+		},
+		{
+			name:   "SKI not allowed for an inactive limit",
+			policy: hemsconfig.ApprovalPolicy{AllowedSKIs: []string{otherSKI}},
+			write:  ucapi.LoadLimit{Value: 3000},
+			reason: "SKI not allowed",
+		},
+		{
+			name:   "zero duration",
+			write:  ucapi.LoadLimit{Value: 3000, IsActive: true},
+			reason: "Duration zero",
+		},
+		{
+			name:    "zero duration allowed",
+			policy:  hemsconfig.ApprovalPolicy{AllowZeroDuration: true},
+			write:   ucapi.LoadLimit{Value: 3000, IsActive: true},
+			approve: true,
+		},
+		{
+			name:    "zero duration allowed with max duration",
+			policy:  hemsconfig.ApprovalPolicy{AllowZeroDuration: true, MaxDuration: 3600},
+			write:   ucapi.LoadLimit{Value: 3000, IsActive: true},
+			approve: true,
+		},
+		{
+			name:    "at max duration",
+			policy:  hemsconfig.ApprovalPolicy{MaxDuration: 3600},
+			write:   ucapi.LoadLimit{Value: 3000, Duration: time.Hour, IsActive: true},
+			approve: true,
+		},
+		{
+			name:   "above max duration",
+			policy: hemsconfig.ApprovalPolicy{MaxDuration: 3600},
+			write:  ucapi.LoadLimit{Value: 3000, Duration: time.Hour + time.Second, IsActive: true},
+			reason: "Duration above 3600 s",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			approve, reason := evaluatePolicy(test.policy, testSKI, test.write, test.write.Value)
+			s.Equal(test.approve, approve)
+			s.Equal(test.reason, reason)
+		})
+	}
+}
+
+// a decision sent to the remote
+type decision struct {
+	msgCounter model.MsgCounterType
+	approve    bool
+	reason     string
+}
+
+// return an LPC approver with the policy recording the decisions sent to the remote
+func newTestApprover(policy hemsconfig.ApprovalPolicy) (*limitApprover, func() []decision) {
+	var mux sync.Mutex
+	var decisions []decision
+
+	approver := newLimitApprover("lpc",
+		func() hemsconfig.ApprovalPolicy { return policy },
+		consumptionValue,
+		func(msgCounter model.MsgCounterType, approve bool, reason string) {
+			mux.Lock()
+			defer mux.Unlock()
+			decisions = append(decisions, decision{msgCounter, approve, reason})
+		})
+
+	return approver, func() []decision {
+		mux.Lock()
+		defer mux.Unlock()
+		return append([]decision(nil), decisions...)
+	}
+}
+
+func (s *HemsSuite) Test_Approver_Automatic() {
+	minValue := 1000.0
+	approver, decisions := newTestApprover(hemsconfig.ApprovalPolicy{MinValue: &minValue, Reason: "Not allowed"})
+
+	approver.handle(testSKI, map[model.MsgCounterType]ucapi.LoadLimit{
+		1: {Value: 3000, Duration: time.Hour, IsActive: true},
+	})
+	approver.handle(testSKI, map[model.MsgCounterType]ucapi.LoadLimit{
+		2: {Value: 500, Duration: time.Hour, IsActive: true},
+	})
+	approver.handle(testSKI, map[model.MsgCounterType]ucapi.LoadLimit{
+		3: {Value: -500, Duration: time.Hour, IsActive: true},
+	})
+
+	// the reason of the policy is sent instead of the rule
+	s.Equal([]decision{{1, true, ""}, {2, false, "Not allowed"}, {3, false, "Not allowed"}}, decisions())
+	s.Equal([]string{
+		`{"id":1,"approved":true,"manual":false}`,
+		`{"id":2,"approved":false,"reason":"Value below 1000 W","manual":false}`,
+		`{"id":3,"approved":false,"reason":"Value \u003c 0","manual":false}`,
+	}, s.client.payloads("eebus2mqtt/hems/lpc/approval/result"))
+}
+
+func (s *HemsSuite) Test_Approver_ManualApprove() {
+	approver, decisions := newTestApprover(hemsconfig.ApprovalPolicy{Manual: true, ManualTimeout: 60})
+
+	approver.handle(testSKI, map[model.MsgCounterType]ucapi.LoadLimit{
+		1: {Value: 3000, Duration: time.Hour, IsActive: true},
+	})
+	s.Empty(decisions())
+	s.Equal([]string{
+		`{"id":1,"ski":"` + testSKI + `","value":3000,"duration":3600,"active":true,"timeout":60}`,
+	}, s.client.payloads("eebus2mqtt/hems/lpc/approval/request"))
+
+	// a pending limit is not requested twice
+	approver.handle(testSKI, map[model.MsgCounterType]ucapi.LoadLimit{
+		1: {Value: 3000, Duration: time.Hour, IsActive: true},
+	})
+	s.Len(s.client.payloads("eebus2mqtt/hems/lpc/approval/request"), 1)
+
+	decided, err := approver.decideManual([]byte("approve"))
+	s.Nil(err)
+	s.Equal(1, decided)
+	s.Equal([]decision{{1, true, ""}}, decisions())
+	s.Equal([]string{`{"id":1,"approved":true,"manual":true}`}, s.client.payloads("eebus2mqtt/hems/lpc/approval/result"))
+
+	// nothing is pending anymore
+	_, err = approver.decideManual([]byte("approve"))
+	s.EqualError(err, "no pending approval")
+}
+
+func (s *HemsSuite) Test_Approver_ManualDeny() {
+	approver, decisions := newTestApprover(hemsconfig.ApprovalPolicy{Manual: true, ManualTimeout: 60})
+
+	approver.handle(testSKI, map[model.MsgCounterType]ucapi.LoadLimit{
+		1: {Value: 3000, Duration: time.Hour, IsActive: true},
+		2: {Value: 4000, Duration: time.Hour, IsActive: true},
+	})
+
+	// a decision with id only decides that limit
+	decided, err := approver.decideManual([]byte(`{"id":2,"approve":false,"reason":"Too high"}`))
+	s.Nil(err)
+	s.Equal(1, decided)
+	s.Equal([]decision{{2, false, "Too high"}}, decisions())
+
+	decided, err = approver.decideManual([]byte("deny"))
+	s.Nil(err)
+	s.Equal(1, decided)
+	s.Equal([]decision{{2, false, "Too high"}, {1, false, "Denied manually"}}, decisions())
+
+	_, err = approver.decideManual([]byte("maybe"))
+	s.NotNil(err)
+}
+
+func (s *HemsSuite) Test_Approver_ManualDenyKeepsReason() {
+	approver, decisions := newTestApprover(hemsconfig.ApprovalPolicy{Manual: true, ManualTimeout: 60, Reason: "Not allowed"})
+
+	approver.handle(testSKI, map[model.MsgCounterType]ucapi.LoadLimit{
+		1: {Value: 3000, Duration: time.Hour, IsActive: true},
+	})
+	approver.handle(testSKI, map[model.MsgCounterType]ucapi.LoadLimit{
+		2: {Value: 4000, Duration: time.Hour, IsActive: true},
+	})
+
+	// the reason of the policy only replaces denials by the policy
+	_, err := approver.decideManual([]byte(`{"id":1,"approve":false,"reason":"Too high"}`))
+	s.Nil(err)
+	_, err = approver.decideManual([]byte("deny"))
+	s.Nil(err)
+	s.Equal([]decision{{1, false, "Too high"}, {2, false, "Denied manually"}}, decisions())
+}
+
+func (s *HemsSuite) Test_Approver_ManualTimeout() {
+	approver, decisions := newTestApprover(hemsconfig.ApprovalPolicy{Manual: true, ManualTimeout: 1, Reason: "Not allowed"})
+
+	approver.handle(testSKI, map[model.MsgCounterType]ucapi.LoadLimit{
+		1: {Value: 3000, Duration: time.Hour, IsActive: true},
+	})
+
+	s.Eventually(func() bool { return len(decisions()) == 1 }, 3*time.Second, 10*time.Millisecond)
+	// the timeout is a denial by the policy
+	s.Equal([]decision{{1, false, "Not allowed"}}, decisions())
+	s.Equal([]string{`{"id":1,"approved":false,"reason":"Manual approval timeout","manual":true}`},
+		s.client.payloads("eebus2mqtt/hems/lpc/approval/result"))
+
+	// a late decision finds nothing to decide
+	_, err := approver.decideManual([]byte("approve"))
+	s.EqualError(err, "no pending approval")
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"crypto/rand"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/enbility/eebus-go/devices/hems/certs"
+	shiputil "github.com/enbility/ship-go/util"
+)
+
+// SHIP certificate management
+//
+// The certificate is created on the first start or with the ski command. With
+// the cert subcommand it can be shown, exported, imported from PEM files and
+// rotated, import and rotate also create it:
+//
+//	hems cert show
+//	hems cert export [<cert.pem> [<key.pem>]]
+//	hems cert import [-yes] <cert.pem> [<key.pem>]
+//	hems cert rotate [-yes]
+const certUsage = `usage:
+  hems cert show                              show the SKI, the validity and the remotes to pair again
+  hems cert export [<cert.pem> [<key.pem>]]   write the certificate and key, without files the certificate to stdout
+  hems cert import [-yes] <cert.pem> [<key.pem>]   use the certificate and key of PEM files
+  hems cert rotate [-yes]                     create a new certificate
+`
+
+var errNoCertificate = errors.New("no certificate, start the bridge or create one with: hems cert rotate")
+
+// return the certificate and key of the config
+func certificatePair() certs.Pair {
+	return certs.Pair{Cert: config.Hems.CertFile, Key: config.Hems.KeyFile}
+}
+
+// return the certificate and key of the config or errNoCertificate
+func existingCertificatePair() (certs.Pair, error) {
+	pair := certificatePair()
+	if pair.Cert == "" || pair.Key == "" {
+		return certs.Pair{}, errNoCertificate
+	}
+	return pair, nil
+}
+
+// return the serial number of the config or a new one if there is none yet
+func serialNumber() (string, error) {
+	if sn := hemsConfig().SN; sn != "" {
+		return sn, nil
+	}
+	return newSerialNumber()
+}
+
+// store the certificate and key in the config, configMux must be held
+func setCertificatePair(p certs.Pair) {
+	config.Hems.CertFile = p.Cert
+	config.Hems.KeyFile = p.Key
+}
+
+// return a random serial number with 10 digits
+func newSerialNumber() (string, error) {
+	const digits = "0123456789"
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = digits[int(b[i])%10]
+	}
+	return string(b), nil
+}
+
+// create the serial number and the certificate on the first run
+func ensureCertificate() {
+	if cfg := hemsConfig(); cfg.CertFile != "" && cfg.KeyFile != "" && cfg.SN != "" {
+		return
+	}
+
+	bridgeLog().Info("no certificate found, generating it", "path", store.Path())
+
+	sn, err := newSerialNumber()
+	if err != nil {
+		fatal("unable to create the serial number", "error", err)
+	}
+	pair, err := certs.Create(sn)
+	if err != nil {
+		fatal("unable to create the certificate", "error", err)
+	}
+	updateConfig(func() { setCertificate(sn, pair) })
+}
+
+// store the serial number, the certificate and a port, configMux must be held
+func setCertificate(sn string, pair certs.Pair) {
+	config.Hems.SN = sn
+	setCertificatePair(pair)
+
+	// choose a starting port and find the next free one
+	if config.Hems.Port == 0 {
+		config.Hems.Port = availablePort(4713)
+	}
+}
+
+// warn about an expiring certificate and remotes paired with another certificate
+func checkCertificate(info certs.Info) {
+	now := time.Now()
+	switch {
+	case info.Expired(now):
+		bridgeLog().Warn("the certificate expired, rotate it with: hems cert rotate", "not_after", info.NotAfter.Format(time.DateOnly))
+		logs.Audit().Info("certificate expired", "not_after", info.NotAfter.Format(time.DateOnly))
+	case info.Remaining(now) < certs.ExpiryWarning:
+		bridgeLog().Warn("the certificate expires soon, rotate it with: hems cert rotate", "not_after", info.NotAfter.Format(time.DateOnly))
+	}
+
+	for _, remote := range remotesToPairAgain(info.SKI) {
+		bridgeLog().Warn("remote was paired with the previous certificate and has to be paired again", "remote", remoteName(remote.SKI), "local_ski", info.SKI)
+		logs.Audit().Info("remote has to be paired again", "ski", remote.SKI)
+		publishRemote(remote.SKI, "pair_again", "true")
+	}
+}
+
+// run the cert subcommand, returns the exit code
+func runCertCommand(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, certUsage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("cert "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	yes := fs.Bool("yes", false, "change the certificate although the SKI changes")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "show":
+		err = certShow(stdout)
+	case "export":
+		err = certExport(stdout, fs.Args())
+	case "import":
+		if fs.NArg() < 1 || fs.NArg() > 2 {
+			fmt.Fprint(stderr, certUsage)
+			return 2
+		}
+		err = certImport(stdout, stderr, fs.Arg(0), fs.Arg(1), *yes)
+	case "rotate":
+		err = certRotate(stdout, stderr, *yes)
+	default:
+		fmt.Fprint(stderr, certUsage)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	return 0
+}
+
+// print a description of the current certificate
+func certShow(w io.Writer) error {
+	pair, err := existingCertificatePair()
+	if err != nil {
+		return err
+	}
+	_, info, err := certs.Parse(pair)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	validity := fmt.Sprintf("%d days left", int(info.Remaining(now).Hours()/24))
+	if info.Expired(now) {
+		validity = "EXPIRED"
+	}
+
+	fmt.Fprintf(w, "SKI:         %s\n", info.SKI)
+	fmt.Fprintf(w, "Subject:     %s\n", info.Subject)
+	fmt.Fprintf(w, "Valid from:  %s\n", info.NotBefore.Format(time.RFC3339))
+	fmt.Fprintf(w, "Valid until: %s (%s)\n", info.NotAfter.Format(time.RFC3339), validity)
+
+	if remotes := remotesToPairAgain(info.SKI); len(remotes) > 0 {
+		fmt.Fprintln(w, "Remotes to pair again:")
+		for _, remote := range remotes {
+			fmt.Fprintf(w, "  %s (%s)\n", remoteName(remote.SKI), remote.SKI)
+		}
+	}
+
+	return nil
+}
+
+// write the certificate and key to files or the certificate to w
+func certExport(w io.Writer, files []string) error {
+	pair, err := existingCertificatePair()
+	if err != nil {
+		return err
+	}
+	if _, _, err := certs.Parse(pair); err != nil {
+		return err
+	}
+
+	switch len(files) {
+	case 0:
+		_, err := io.WriteString(w, pair.Cert)
+		return err
+	case 1:
+		return certs.Export(pair, files[0], "")
+	case 2:
+		return certs.Export(pair, files[0], files[1])
+	default:
+		return fmt.Errorf("too many arguments")
+	}
+}
+
+// replace the certificate with the one of PEM files
+func certImport(stdout, stderr io.Writer, certFile, keyFile string, yes bool) error {
+	pair, info, err := certs.Import(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	if err := checkBridgeStopped(); err != nil {
+		return err
+	}
+
+	if !confirmSKIChange(stderr, info.SKI, yes) {
+		return fmt.Errorf("certificate not imported")
+	}
+
+	// the bridge creates a new certificate if the serial number is missing
+	sn, err := serialNumber()
+	if err != nil {
+		return err
+	}
+
+	configMux.Lock()
+	setCertificate(sn, pair)
+	err = store.Save(config)
+	configMux.Unlock()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Imported the certificate, the local SKI is %s.\n", info.SKI)
+
+	return nil
+}
+
+// replace the certificate with a new one
+func certRotate(stdout, stderr io.Writer, yes bool) error {
+	if err := checkBridgeStopped(); err != nil {
+		return err
+	}
+	if !confirmSKIChange(stderr, "", yes) {
+		return fmt.Errorf("certificate not rotated")
+	}
+
+	sn, err := serialNumber()
+	if err != nil {
+		return err
+	}
+	pair, err := certs.Create(sn)
+	if err != nil {
+		return err
+	}
+	_, info, err := certs.Parse(pair)
+	if err != nil {
+		return err
+	}
+
+	configMux.Lock()
+	setCertificate(sn, pair)
+	err = store.Save(config)
+	configMux.Unlock()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Rotated the certificate, the local SKI is %s.\n", info.SKI)
+
+	return nil
+}
+
+// warn that the local SKI changes and the remotes have to be paired again
+//
+// newSKI is empty if it is not known yet, returns true if the change is confirmed
+// or there is no certificate yet
+func confirmSKIChange(w io.Writer, newSKI string, yes bool) bool {
+	_, info, err := certs.Parse(certificatePair())
+	if err != nil {
+		return true
+	}
+	oldSKI := info.SKI
+	if shiputil.NormalizeSKI(oldSKI) == shiputil.NormalizeSKI(newSKI) {
+		return true
+	}
+
+	if newSKI != "" {
+		fmt.Fprintf(w, "WARNING: the local SKI %s will change to %s.\n", oldSKI, newSKI)
+	} else {
+		fmt.Fprintf(w, "WARNING: the local SKI %s will change.\n", oldSKI)
+	}
+	if remotes := remoteConfigs(); len(remotes) > 0 {
+		names := make([]string, 0, len(remotes))
+		for _, remote := range remotes {
+			names = append(names, remoteName(remote.SKI))
+		}
+		fmt.Fprintf(w, "These remotes have to be paired again with the new SKI: %s\n", strings.Join(names, ", "))
+	}
+	fmt.Fprintln(w, "Stop the bridge before changing the certificate, a running bridge keeps the old one.")
+
+	if !yes {
+		fmt.Fprintln(w, "Run again with -yes to change the certificate.")
+	}
+	return yes
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/enbility/eebus-go/devices/hems/certs"
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+)
+
+// store a new certificate in the config and return its SKI
+func (s *HemsSuite) createCertificate() string {
+	pair, err := certs.Create("0123456789")
+	s.Require().NoError(err)
+	_, info, err := certs.Parse(pair)
+	s.Require().NoError(err)
+
+	config.Hems.SN = "0123456789"
+	setCertificatePair(pair)
+	return info.SKI
+}
+
+func (s *HemsSuite) Test_RunCertCommand_ArgumentErrors() {
+	for _, args := range [][]string{
+		{},
+		{"renew"},
+		{"show", "-unknown"},
+		{"import"},
+		{"import", "cert.pem", "key.pem", "other.pem"},
+	} {
+		var stdout, stderr bytes.Buffer
+		s.Equal(2, runCertCommand(args, &stdout, &stderr), args)
+		s.Empty(stdout.String())
+		s.Contains(strings.ToLower(stderr.String()), "usage", args)
+	}
+}
+
+func (s *HemsSuite) Test_RunCertCommand_NoCertificate() {
+	for _, args := range [][]string{{"show"}, {"export"}} {
+		var stdout, stderr bytes.Buffer
+		s.Equal(1, runCertCommand(args, &stdout, &stderr), args)
+		s.Equal("Error: "+errNoCertificate.Error()+"\n", stderr.String())
+	}
+
+	// no certificate is created as a side effect
+	s.Empty(config.Hems.CertFile)
+	s.Empty(config.Hems.SN)
+}
+
+func (s *HemsSuite) Test_RunCertCommand_Show() {
+	ski := s.createCertificate()
+	config.Hems.Remotes = []hemsconfig.Remote{{SKI: testSKI, Name: "wallbox", LocalSKI: otherSKI}}
+
+	var stdout, stderr bytes.Buffer
+	s.Equal(0, runCertCommand([]string{"show"}, &stdout, &stderr))
+	s.Empty(stderr.String())
+	s.Contains(stdout.String(), "SKI:         "+ski+"\n")
+	s.Contains(stdout.String(), "Remotes to pair again:\n  wallbox ("+testSKI+")\n")
+
+	stdout.Reset()
+	s.Equal(0, runCertCommand([]string{"export"}, &stdout, &stderr))
+	s.Equal(config.Hems.CertFile, stdout.String())
+}
+
+func (s *HemsSuite) Test_ConfirmSKIChange() {
+	var w bytes.Buffer
+
+	// without a certificate nothing changes
+	s.True(confirmSKIChange(&w, otherSKI, false))
+	s.Empty(w.String())
+
+	ski := s.createCertificate()
+	s.True(confirmSKIChange(&w, ski, false))
+	s.Empty(w.String())
+
+	config.Hems.Remotes = []hemsconfig.Remote{{SKI: testSKI, Name: "wallbox"}, {SKI: otherSKI}}
+	s.False(confirmSKIChange(&w, otherSKI, false))
+	s.Equal("WARNING: the local SKI "+ski+" will change to "+otherSKI+".\n"+
+		"These remotes have to be paired again with the new SKI: wallbox, "+otherSKI+"\n"+
+		"Stop the bridge before changing the certificate, a running bridge keeps the old one.\n"+
+		"Run again with -yes to change the certificate.\n", w.String())
+
+	w.Reset()
+	s.True(confirmSKIChange(&w, "", true))
+	s.Equal("WARNING: the local SKI "+ski+" will change.\n"+
+		"These remotes have to be paired again with the new SKI: wallbox, "+otherSKI+"\n"+
+		"Stop the bridge before changing the certificate, a running bridge keeps the old one.\n", w.String())
+}
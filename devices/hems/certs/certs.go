@@ -0,0 +1,187 @@
+// Package certs manages the SHIP certificate of the HEMS bridge
+//
+// The certificate and key are stored as PEM in the config. They can be created,
+// imported from PEM files, exported, inspected and rotated. A new certificate
+// changes the local SKI, remotes paired with the old SKI have to be paired again.
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/ship-go/cert"
+)
+
+// ExpiryWarning is the remaining validity below which a warning should be shown
+const ExpiryWarning = 30 * 24 * time.Hour
+
+// Pair is a certificate with its private key, both PEM encoded
+type Pair struct {
+	Cert string
+	Key  string
+}
+
+// Info describes a certificate
+type Info struct {
+	SKI       string
+	Subject   string
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// Remaining returns the remaining validity at the given time
+func (i Info) Remaining(now time.Time) time.Duration {
+	return i.NotAfter.Sub(now)
+}
+
+// Expired checks if the certificate is not valid at the given time
+func (i Info) Expired(now time.Time) bool {
+	return now.Before(i.NotBefore) || now.After(i.NotAfter)
+}
+
+// Create returns a new certificate for the serial number
+func Create(serial string) (Pair, error) {
+	certificate, err := cert.CreateCertificate("eebus2mqtt", "eebus-go", "HEMS", serial)
+	if err != nil {
+		return Pair{}, err
+	}
+
+	key, err := x509.MarshalECPrivateKey(certificate.PrivateKey.(*ecdsa.PrivateKey))
+	if err != nil {
+		return Pair{}, err
+	}
+
+	return Pair{
+		Cert: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Certificate[0]})),
+		Key:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key})),
+	}, nil
+}
+
+// Parse checks the pair and returns the TLS certificate
+//
+// SHIP requires an ECDSA P-256 key and a certificate with a subject key identifier.
+func Parse(p Pair) (tls.Certificate, Info, error) {
+	certificate, err := tls.X509KeyPair([]byte(p.Cert), []byte(p.Key))
+	if err != nil {
+		return tls.Certificate{}, Info{}, fmt.Errorf("invalid certificate or key: %w", err)
+	}
+
+	leaf := certificate.Leaf
+	if leaf == nil {
+		if leaf, err = x509.ParseCertificate(certificate.Certificate[0]); err != nil {
+			return tls.Certificate{}, Info{}, err
+		}
+	}
+
+	publicKey, ok := leaf.PublicKey.(*ecdsa.PublicKey)
+	if !ok || publicKey.Curve != elliptic.P256() {
+		return tls.Certificate{}, Info{}, errors.New("SHIP requires an ECDSA P-256 key")
+	}
+
+	ski, err := cert.SkiFromCertificate(leaf)
+	if err != nil {
+		return tls.Certificate{}, Info{}, errors.New("the certificate has no subject key identifier (SKI)")
+	}
+
+	info := Info{
+		SKI:       ski,
+		Subject:   leaf.Subject.String(),
+		NotBefore: leaf.NotBefore,
+		NotAfter:  leaf.NotAfter,
+	}
+
+	return certificate, info, nil
+}
+
+// Apply sets the certificate of the service configuration
+func Apply(configuration *api.Configuration, p Pair) (Info, error) {
+	certificate, info, err := Parse(p)
+	if err != nil {
+		return Info{}, err
+	}
+
+	configuration.SetCertificate(certificate)
+	return info, nil
+}
+
+// Import reads a pair from PEM files
+//
+// The key may be contained in the certificate file, keyFile is optional then.
+func Import(certFile, keyFile string) (Pair, Info, error) {
+	certData, err := os.ReadFile(certFile)
+	if err != nil {
+		return Pair{}, Info{}, err
+	}
+	keyData := certData
+	if keyFile != "" {
+		if keyData, err = os.ReadFile(keyFile); err != nil {
+			return Pair{}, Info{}, err
+		}
+	}
+
+	p := Pair{
+		Cert: pemBlocks(certData, func(t string) bool { return t == "CERTIFICATE" }),
+		Key:  pemBlocks(keyData, func(t string) bool { return strings.HasSuffix(t, "PRIVATE KEY") }),
+	}
+	if p.Cert == "" {
+		return Pair{}, Info{}, fmt.Errorf("no certificate found in %s", certFile)
+	}
+	if p.Key == "" {
+		return Pair{}, Info{}, errors.New("no private key found")
+	}
+
+	_, info, err := Parse(p)
+	if err != nil {
+		return Pair{}, Info{}, err
+	}
+	return p, info, nil
+}
+
+// return the PEM blocks of the given types
+func pemBlocks(data []byte, match func(blockType string) bool) string {
+	var out []byte
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return string(out)
+		}
+		if match(block.Type) {
+			out = append(out, pem.EncodeToMemory(block)...)
+		}
+	}
+}
+
+// Export writes the certificate and, if keyFile is set, the key to PEM files
+//
+// Existing files are not overwritten, the key file is readable by the owner only.
+func Export(p Pair, certFile, keyFile string) error {
+	if err := writeNew(certFile, p.Cert, 0644); err != nil {
+		return err
+	}
+	if keyFile == "" {
+		return nil
+	}
+	return writeNew(keyFile, p.Key, 0600)
+}
+
+func writeNew(path, data string, mode os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,109 @@
+package certs
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/enbility/eebus-go/api"
+	shipapi "github.com/enbility/ship-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+func (s *CertsSuite) Test_Parse() {
+	certificate, info, err := Parse(s.pair)
+	s.Nil(err)
+	s.NotEmpty(certificate.Certificate)
+	s.Len(info.SKI, 40)
+	s.Contains(info.Subject, "CN=0123456789")
+
+	now := time.Now()
+	s.False(info.Expired(now))
+	s.Greater(info.Remaining(now), 9*365*24*time.Hour)
+	s.True(info.Expired(info.NotAfter.Add(time.Second)))
+
+	// a key of another certificate does not match
+	other, err := Create("0123456789")
+	s.Require().NoError(err)
+	_, _, err = Parse(Pair{Cert: s.pair.Cert, Key: other.Key})
+	s.ErrorContains(err, "invalid certificate or key")
+}
+
+func (s *CertsSuite) Test_Parse_RSA() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "rsa"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		SubjectKeyId: make([]byte, 20),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	s.Require().NoError(err)
+
+	p := Pair{
+		Cert: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		Key:  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+	}
+	_, _, err = Parse(p)
+	s.ErrorContains(err, "ECDSA P-256")
+}
+
+func (s *CertsSuite) Test_Apply() {
+	configuration, err := api.NewConfiguration(
+		"test", "test", "test", "test",
+		[]shipapi.DeviceCategoryType{shipapi.DeviceCategoryTypeEnergyManagementSystem},
+		model.DeviceTypeTypeEnergyManagementSystem,
+		[]model.EntityTypeType{model.EntityTypeTypeCEM},
+		9999, tls.Certificate{}, time.Second*4)
+	s.Require().NoError(err)
+
+	info, err := Apply(configuration, s.pair)
+	s.Nil(err)
+	s.Len(info.SKI, 40)
+	s.NotEmpty(configuration.Certificate().Certificate)
+
+	_, err = Apply(configuration, Pair{})
+	s.NotNil(err)
+}
+
+func (s *CertsSuite) Test_ImportExport() {
+	certFile := filepath.Join(s.dir, "cert.pem")
+	keyFile := filepath.Join(s.dir, "key.pem")
+	s.Nil(Export(s.pair, certFile, keyFile))
+
+	info, err := os.Stat(keyFile)
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	// existing files are not overwritten
+	s.NotNil(Export(s.pair, certFile, ""))
+
+	p, certInfo, err := Import(certFile, keyFile)
+	s.Nil(err)
+	s.Equal(s.pair, p)
+	s.Len(certInfo.SKI, 40)
+
+	// combined file
+	combined := s.writeFile("combined.pem", s.pair.Key+s.pair.Cert)
+	p, _, err = Import(combined, "")
+	s.Nil(err)
+	s.Equal(s.pair, p)
+
+	_, _, err = Import(keyFile, "")
+	s.ErrorContains(err, "no certificate found")
+
+	_, _, err = Import(certFile, "")
+	s.ErrorContains(err, "no private key found")
+
+	_, _, err = Import(filepath.Join(s.dir, "missing.pem"), "")
+	s.NotNil(err)
+}
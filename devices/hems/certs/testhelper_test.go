@@ -0,0 +1,35 @@
+package certs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCertsSuite(t *testing.T) {
+	suite.Run(t, new(CertsSuite))
+}
+
+type CertsSuite struct {
+	suite.Suite
+
+	dir  string
+	pair Pair
+}
+
+func (s *CertsSuite) BeforeTest(suiteName, testName string) {
+	s.dir = s.T().TempDir()
+
+	var err error
+	s.pair, err = Create("0123456789")
+	s.Require().NoError(err)
+}
+
+// write a file in the test directory
+func (s *CertsSuite) writeFile(name, content string) string {
+	path := filepath.Join(s.dir, name)
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0600))
+	return path
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/enbility/eebus-go/devices/hems/certs"
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+	"github.com/enbility/eebus-go/service"
+	shiputil "github.com/enbility/ship-go/util"
+)
+
+// Command line interface
+//
+//	hems [flags] [command] [arguments]
+//
+// The flags select the config file, the data directory, the log level and
+// override config values. Without a command the bridge is run.
+
+// time to wait for the retained availability of a running bridge
+const availabilityWait = 2 * time.Second
+
+// time to wait for the MQTT broker before a command changes the config file
+const offlineCheckTimeout = 5 * time.Second
+
+// a command line command, returns the exit code
+type cliCommand struct {
+	name  string
+	usage string
+	run   func(args []string) int
+}
+
+var cliCommands = []cliCommand{
+	{"run", "run                                        run the bridge, the default", runRunCommand},
+	{"ski", "ski                                        print the local SKI and the QR code text", runSKICommand},
+	{"pair", "pair [-name <name>] [-ship-id <id>] <ski>  pair a remote", runPairCommand},
+	{"config", "config validate|show                       validate or print the config", runConfigCommand},
+	{"status", "status [-json] [-timeout <duration>]       query the status of the running bridge", runStatusCommand},
+	{"cert", "cert show|export|import|rotate             manage the SHIP certificate", func(args []string) int {
+		loadConfig(overrides...)
+		return runCertCommand(args, os.Stdout, os.Stderr)
+	}},
+}
+
+// the overrides of the environment and the flags
+var overrides []hemsconfig.Overrides
+
+// print the usage of the global flags and the commands
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "usage: %s [flags] [command] [arguments]\n\ncommands:\n", os.Args[0])
+	for _, command := range cliCommands {
+		fmt.Fprintf(out, "  %s\n", command.usage)
+	}
+	fmt.Fprintln(out, "\nflags:")
+	flag.PrintDefaults()
+}
+
+// run the command of the arguments, returns the exit code
+func runCommand(args []string, o ...hemsconfig.Overrides) int {
+	overrides = o
+
+	if len(args) == 0 {
+		return runRunCommand(nil)
+	}
+
+	// the positional debug argument of older releases
+	if args[0] == "debug" {
+		bridgeLog().Warn("the argument debug is deprecated, use -log-level debug")
+		overrides = append(overrides, hemsconfig.Overrides{"log-level": "debug"})
+		return runRunCommand(args[1:])
+	}
+
+	for _, command := range cliCommands {
+		if command.name == args[0] {
+			return command.run(args[1:])
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+	flag.Usage()
+	return 2
+}
+
+// return a flag set of a command writing errors to stderr
+func commandFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+func runRunCommand(args []string) int {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, "run takes no arguments, set the flags before the command")
+		return 2
+	}
+
+	loadConfig(overrides...)
+	return runBridge()
+}
+
+// print the local SKI and the QR code text for pairing
+func runSKICommand(args []string) int {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, "ski takes no arguments")
+		return 2
+	}
+
+	loadConfig(overrides...)
+	ensureCertificate()
+
+	configuration, _, err := newConfiguration(config.Hems.Port)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+
+	// the service is set up to build the QR code text, it is not started
+	h := hems{}
+	svc := service.NewService(configuration, &h)
+	if err := svc.Setup(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+
+	fmt.Println("SKI:    ", svc.LocalService().SKI())
+	fmt.Println("QR code:", svc.QRCodeText())
+	return 0
+}
+
+// pair a remote via the running bridge or add it to the config
+//
+// A running bridge that is not connected to the broker keeps its config in
+// memory and overwrites the added remote on its next save, it has to be
+// restarted.
+func runPairCommand(args []string) int {
+	fs := commandFlags("pair")
+	name := fs.String("name", "", "MQTT namespace of the remote, default is the SKI")
+	shipID := fs.String("ship-id", "", "SHIP ID of the remote, default is the ID found via mDNS")
+	timeout := fs.Duration("timeout", 10*time.Second, "time to wait for the MQTT broker and the bridge")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: pair [-name <name>] [-ship-id <id>] <ski>")
+		return 2
+	}
+
+	remote := hemsconfig.Remote{SKI: shiputil.NormalizeSKI(fs.Arg(0)), Name: *name, ShipID: *shipID}
+	if err := remote.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+
+	loadConfig(overrides...)
+	ensureCertificate()
+	if _, info, err := certs.Parse(certificatePair()); err == nil && shiputil.NormalizeSKI(info.SKI) == remote.SKI {
+		fmt.Fprintln(os.Stderr, "Error: the local SKI can not be paired")
+		return 1
+	}
+	if err := setupSecrets(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+
+	// a running bridge pairs the remote at once and keeps the config in memory
+	c, err := mqttDial(*timeout)
+	if err != nil {
+		bridgeLog().Warn("bridge not reachable, adding the remote to the config", "error", err)
+	} else {
+		defer c.Disconnect(250)
+
+		if bridgeOnline(c) {
+			payload, _ := json.Marshal(remote)
+			_, ack, err := sendCommand(c, pairingTopic+"/pair", payload, *timeout)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				return 1
+			}
+			if !ack.Success {
+				fmt.Fprintln(os.Stderr, "Error:", ack.Error)
+				return 1
+			}
+			fmt.Printf("Pairing with %s started, accept the pairing on the remote.\n", remote.SKI)
+			return 0
+		}
+	}
+
+	configMux.Lock()
+	remote, err = addRemoteConfig(remote)
+	if err == nil {
+		err = store.Save(config)
+	}
+	configMux.Unlock()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+	fmt.Printf("Added remote %s to %s, it is paired on the next start of the bridge.\n", remote.SKI, store.Path())
+
+	// a running bridge that is not connected to the broker overwrites the file with its config
+	if c == nil {
+		fmt.Println("The bridge was not reachable. If it is running, restart it now, otherwise it removes the remote again.")
+	}
+	return 0
+}
+
+// validate or print the config
+func runConfigCommand(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: config validate|show")
+		return 2
+	}
+
+	switch args[0] {
+	case "validate":
+		return configValidate(os.Stdout, os.Stderr)
+	case "show":
+		return configShow(os.Stdout, os.Stderr)
+	default:
+		fmt.Fprintln(os.Stderr, "usage: config validate|show")
+		return 2
+	}
+}
+
+// check the config file without creating it
+func configValidate(stdout, stderr io.Writer) int {
+	path := hemsconfig.FilePath(overrides...)
+	if _, err := os.Stat(path); err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+
+	if _, _, err := hemsconfig.Load(overrides...); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "config %s is valid\n", path)
+	return 0
+}
+
+// print the config with the overrides applied and the secrets hidden
+func configShow(stdout, stderr io.Writer) int {
+	c, s, err := hemsconfig.Load(overrides...)
+	if s == nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	data, encodeErr := s.Encode(hiddenSecrets(c))
+	if encodeErr != nil {
+		fmt.Fprintln(stderr, "Error:", encodeErr)
+		return 1
+	}
+	stdout.Write(data)
+
+	// show the config also if it is invalid
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// return the config with the private key and the passwords replaced
+func hiddenSecrets(c hemsconfig.Config) hemsconfig.Config {
+	const hidden = "***"
+
+	if c.Hems.KeyFile != "" {
+		c.Hems.KeyFile = hidden
+	}
+	if c.Mqtt.Password != "" {
+		c.Mqtt.Password = hidden
+	}
+	// a client key given as a path is shown
+	if strings.Contains(c.Mqtt.KeyFile, "PRIVATE KEY") {
+		c.Mqtt.KeyFile = hidden
+	}
+
+	return c
+}
+
+// return true if the bridge published its availability as online
+func bridgeOnline(c mqtt.Client) bool {
+	online := make(chan bool, 1)
+	token := c.Subscribe(availabilityTopic, qos, func(client mqtt.Client, msg mqtt.Message) {
+		select {
+		case online <- string(msg.Payload()) == payloadOnline:
+		default:
+		}
+	})
+	if !token.WaitTimeout(availabilityWait) || token.Error() != nil {
+		return false
+	}
+	defer c.Unsubscribe(availabilityTopic)
+
+	select {
+	case result := <-online:
+		return result
+	case <-time.After(availabilityWait):
+		return false
+	}
+}
+
+// return an error if the bridge is running
+//
+// The bridge keeps the config in memory and overwrites changes of the file.
+// If the broker is not reachable the bridge is expected to be stopped.
+func checkBridgeStopped() error {
+	if err := setupSecrets(); err != nil {
+		return err
+	}
+
+	c, err := mqttDial(offlineCheckTimeout)
+	if err != nil {
+		bridgeLog().Warn("MQTT broker not reachable, make sure the bridge is stopped", "error", err)
+		return nil
+	}
+	defer c.Disconnect(250)
+
+	if bridgeOnline(c) {
+		return errors.New("the bridge is running, stop it first")
+	}
+	return nil
+}
+
+// send a command to the running bridge and return the acknowledgement
+func sendCommand(c mqtt.Client, name string, payload []byte, timeout time.Duration) (json.RawMessage, commandAck, error) {
+	ackTopic := topicPrefix + name + ackTopicSuffix
+	acks := make(chan []byte, 1)
+	token := c.Subscribe(ackTopic, qos, func(client mqtt.Client, msg mqtt.Message) {
+		select {
+		case acks <- msg.Payload():
+		default:
+		}
+	})
+	if !token.WaitTimeout(timeout) {
+		return nil, commandAck{}, errors.New("MQTT subscription timed out")
+	}
+	if err := token.Error(); err != nil {
+		return nil, commandAck{}, err
+	}
+	defer c.Unsubscribe(ackTopic)
+
+	if token := c.Publish(topicPrefix+name+commandTopicSuffix, qos, false, payload); !token.WaitTimeout(timeout) || token.Error() != nil {
+		return nil, commandAck{}, fmt.Errorf("unable to send the command %s", name)
+	}
+
+	select {
+	case data := <-acks:
+		var ack commandAck
+		if err := json.Unmarshal(data, &ack); err != nil {
+			return nil, commandAck{}, fmt.Errorf("invalid answer of the bridge: %w", err)
+		}
+		return data, ack, nil
+	case <-time.After(timeout):
+		return nil, commandAck{}, errors.New("the bridge did not answer")
+	}
+}
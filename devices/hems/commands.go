@@ -0,0 +1,240 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// MQTT command interface
+//
+// Commands are received on eebus2mqtt/hems/<usecase>/<name>/set with a plain
+// number as payload. The result of every command is published as JSON to
+// eebus2mqtt/hems/<usecase>/<name>/ack.
+const (
+	topicPrefix        = "eebus2mqtt/hems/"
+	commandTopicSuffix = "/set"
+	ackTopicSuffix     = "/ack"
+
+	// subscription filter for all command topics
+	commandTopicFilter = topicPrefix + "+/+" + commandTopicSuffix
+)
+
+// acknowledgement published for every received command
+type commandAck struct {
+	Command string   `json:"command"`
+	Success bool     `json:"success"`
+	Value   *float64 `json:"value,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// a command applies the validated value and returns the value that is now in effect
+type command func(h *hems, value float64) (float64, error)
+
+// available commands, keyed by "<usecase>/<name>"
+var commands = map[string]command{
+	"lpp/nominal_max":       setLPPNominalMax,
+	"lpp/failsafe_limit":    setLPPFailsafeLimit,
+	"lpp/failsafe_duration": setLPPFailsafeDuration,
+	"lpc/nominal_max":       setLPCNominalMax,
+	"lpc/failsafe_limit":    setLPCFailsafeLimit,
+	"lpc/failsafe_duration": setLPCFailsafeDuration,
+}
+
+var errNotReady = errors.New("eebus service not ready")
+
+// handle incoming messages on command topics
+func (h *hems) onCommandMessage(client mqtt.Client, msg mqtt.Message) {
+	// retained commands would be applied again on every reconnect
+	if msg.Retained() {
+		return
+	}
+
+	name, ok := commandName(msg.Topic())
+	if !ok {
+		return
+	}
+
+	if usecase, ok := strings.CutSuffix(name, "/"+approvalCommand); ok {
+		h.onApprovalMessage(client, usecase, msg.Payload())
+		return
+	}
+	if command, ok := strings.CutPrefix(name, pairingTopic+"/"); ok {
+		h.onPairingMessage(client, command, msg.Payload())
+		return
+	}
+	if name == statusCommand {
+		h.onStatusRequest(client)
+		return
+	}
+	if name == logLevelCommand {
+		onLogLevelMessage(client, msg.Payload())
+		return
+	}
+
+	ack := commandAck{Command: name}
+
+	if value, err := h.applyCommand(name, string(msg.Payload())); err != nil {
+		ack.Error = err.Error()
+		auditEvent("MQTT command rejected", "command", name, "error", err)
+	} else {
+		ack.Success = true
+		ack.Value = &value
+		auditEvent("MQTT command applied", "command", name, "value", value)
+
+		// update the state topics
+		h.publishLPP()
+		h.publishLPC()
+	}
+
+	payload, _ := json.Marshal(ack)
+	client.Publish(topicPrefix+name+ackTopicSuffix, qos, false, payload)
+}
+
+// return the command name for a command topic
+func commandName(topic string) (string, bool) {
+	if !strings.HasPrefix(topic, topicPrefix) || !strings.HasSuffix(topic, commandTopicSuffix) {
+		return "", false
+	}
+
+	name := strings.TrimSuffix(strings.TrimPrefix(topic, topicPrefix), commandTopicSuffix)
+	return name, name != ""
+}
+
+// validate the payload and run the command
+func (h *hems) applyCommand(name, payload string) (float64, error) {
+	cmd, ok := commands[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown command %q", name)
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(payload), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("invalid payload %q: a number is required", payload)
+	}
+	if value < 0 {
+		return 0, errors.New("value must not be negative")
+	}
+
+	if h.uccslpp == nil || h.uccslpc == nil {
+		return 0, errNotReady
+	}
+
+	return cmd(h, value)
+}
+
+// check that a power is given in full watts, the config stores integers
+func checkWatts(value float64) error {
+	if value != math.Trunc(value) {
+		return errors.New("power must be given in full watts")
+	}
+	return nil
+}
+
+// set the nominal maximum production power in W
+func setLPPNominalMax(h *hems, value float64) (float64, error) {
+	if err := checkWatts(value); err != nil {
+		return 0, err
+	}
+	if limit, _, err := h.uccslpp.FailsafeProductionActivePowerLimit(); err == nil && value < limit {
+		return 0, fmt.Errorf("value is below the failsafe limit of %.f W", limit)
+	}
+
+	if err := h.uccslpp.SetProductionNominalMax(value); err != nil {
+		return 0, err
+	}
+
+	updateConfig(func() { config.Hems.PVMax = int(value) })
+
+	return value, nil
+}
+
+// set the failsafe production limit in W
+func setLPPFailsafeLimit(h *hems, value float64) (float64, error) {
+	if err := checkWatts(value); err != nil {
+		return 0, err
+	}
+	if nominalMax, err := h.uccslpp.ProductionNominalMax(); err == nil && value > nominalMax {
+		return 0, fmt.Errorf("value exceeds nominal max of %.f W", nominalMax)
+	}
+
+	if err := h.uccslpp.SetFailsafeProductionActivePowerLimit(value, true); err != nil {
+		return 0, err
+	}
+
+	updateConfig(func() { config.Hems.FailsafeValues.LPP.SetLimit(int(value)) })
+
+	return value, nil
+}
+
+// set the minimum LPP failsafe duration in seconds
+func setLPPFailsafeDuration(h *hems, value float64) (float64, error) {
+	if value != math.Trunc(value) {
+		return 0, errors.New("duration must be given in full seconds")
+	}
+
+	if err := h.uccslpp.SetFailsafeDurationMinimum(time.Duration(value)*time.Second, true); err != nil {
+		return 0, err
+	}
+
+	updateConfig(func() { config.Hems.FailsafeValues.LPP.SetDuration(int(value)) })
+
+	return value, nil
+}
+
+// set the nominal maximum consumption power in W
+func setLPCNominalMax(h *hems, value float64) (float64, error) {
+	if err := checkWatts(value); err != nil {
+		return 0, err
+	}
+	if limit, _, err := h.uccslpc.FailsafeConsumptionActivePowerLimit(); err == nil && value < limit {
+		return 0, fmt.Errorf("value is below the failsafe limit of %.f W", limit)
+	}
+
+	if err := h.uccslpc.SetConsumptionNominalMax(value); err != nil {
+		return 0, err
+	}
+
+	updateConfig(func() { config.Hems.LPCMax = int(value) })
+
+	return value, nil
+}
+
+// set the failsafe consumption limit in W
+func setLPCFailsafeLimit(h *hems, value float64) (float64, error) {
+	if err := checkWatts(value); err != nil {
+		return 0, err
+	}
+	if nominalMax, err := h.uccslpc.ConsumptionNominalMax(); err == nil && value > nominalMax {
+		return 0, fmt.Errorf("value exceeds nominal max of %.f W", nominalMax)
+	}
+
+	if err := h.uccslpc.SetFailsafeConsumptionActivePowerLimit(value, true); err != nil {
+		return 0, err
+	}
+
+	updateConfig(func() { config.Hems.FailsafeValues.LPC.SetLimit(int(value)) })
+
+	return value, nil
+}
+
+// set the minimum LPC failsafe duration in seconds
+func setLPCFailsafeDuration(h *hems, value float64) (float64, error) {
+	if value != math.Trunc(value) {
+		return 0, errors.New("duration must be given in full seconds")
+	}
+
+	if err := h.uccslpc.SetFailsafeDurationMinimum(time.Duration(value)*time.Second, true); err != nil {
+		return 0, err
+	}
+
+	updateConfig(func() { config.Hems.FailsafeValues.LPC.SetDuration(int(value)) })
+
+	return value, nil
+}
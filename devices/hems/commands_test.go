@@ -0,0 +1,82 @@
+package main
+
+import (
+	"time"
+
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+)
+
+func (s *HemsSuite) Test_ApplyCommand() {
+	tests := []struct {
+		name    string
+		command string
+		payload string
+		value   float64
+		err     string
+	}{
+		{name: "unknown command", command: "lpp/unknown", payload: "1000", err: `unknown command "lpp/unknown"`},
+		{name: "not a number", command: "lpp/nominal_max", payload: "many", err: `invalid payload "many": a number is required`},
+		{name: "not finite", command: "lpp/nominal_max", payload: "NaN", err: `invalid payload "NaN": a number is required`},
+		{name: "negative", command: "lpc/nominal_max", payload: "-1", err: "value must not be negative"},
+		{name: "fractional nominal max", command: "lpp/nominal_max", payload: "9000.5", err: "power must be given in full watts"},
+		{name: "fractional failsafe limit", command: "lpc/failsafe_limit", payload: "4000.5", err: "power must be given in full watts"},
+		{name: "fractional duration", command: "lpp/failsafe_duration", payload: "7200.5", err: "duration must be given in full seconds"},
+		{name: "LPP nominal max", command: "lpp/nominal_max", payload: " 9000 ", value: 9000},
+		{name: "LPP nominal max below failsafe limit", command: "lpp/nominal_max", payload: "4000", err: "value is below the failsafe limit of 4200 W"},
+		{name: "LPP failsafe limit", command: "lpp/failsafe_limit", payload: "5000", value: 5000},
+		{name: "LPP failsafe limit above nominal max", command: "lpp/failsafe_limit", payload: "11000", err: "value exceeds nominal max of 10000 W"},
+		{name: "LPP failsafe duration", command: "lpp/failsafe_duration", payload: "10800", value: 10800},
+		{name: "LPP failsafe duration out of range", command: "lpp/failsafe_duration", payload: "60", err: "duration outside of allowed range"},
+		{name: "LPC nominal max", command: "lpc/nominal_max", payload: "20000", value: 20000},
+		{name: "LPC nominal max below failsafe limit", command: "lpc/nominal_max", payload: "4199", err: "value is below the failsafe limit of 4200 W"},
+		{name: "LPC failsafe limit", command: "lpc/failsafe_limit", payload: "3000", value: 3000},
+		{name: "LPC failsafe limit above nominal max", command: "lpc/failsafe_limit", payload: "33000", err: "value exceeds nominal max of 32000 W"},
+		{name: "LPC failsafe duration", command: "lpc/failsafe_duration", payload: "86400", value: 86400},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.BeforeTest("", "")
+
+			value, err := s.sut.applyCommand(test.command, test.payload)
+			if test.err != "" {
+				s.EqualError(err, test.err)
+				return
+			}
+			s.Nil(err)
+			s.Equal(test.value, value)
+		})
+	}
+}
+
+func (s *HemsSuite) Test_ApplyCommand_SavesConfig() {
+	_, err := s.sut.applyCommand("lpp/nominal_max", "9000")
+	s.Require().NoError(err)
+	_, err = s.sut.applyCommand("lpc/failsafe_duration", "10800")
+	s.Require().NoError(err)
+
+	s.Equal(9000.0, s.lpp.nominalMax)
+	s.Equal(3*time.Hour, s.lpc.failsafeDuration)
+
+	// the test config has no broker, only the saved values are checked
+	saved, _, _ := hemsconfig.Load(hemsconfig.Overrides{hemsconfig.DataDirKey: store.DataDir()})
+	s.Equal(9000, saved.Hems.PVMax)
+	s.Equal(10800, *saved.Hems.FailsafeValues.LPC.Duration)
+}
+
+func (s *HemsSuite) Test_ApplyCommand_NotReady() {
+	h := hems{}
+	_, err := h.applyCommand("lpp/nominal_max", "9000")
+	s.ErrorIs(err, errNotReady)
+}
+
+func (s *HemsSuite) Test_CommandName() {
+	name, ok := commandName("eebus2mqtt/hems/lpp/nominal_max/set")
+	s.True(ok)
+	s.Equal("lpp/nominal_max", name)
+
+	_, ok = commandName("eebus2mqtt/hems/lpp/nominal_max")
+	s.False(ok)
+	_, ok = commandName("other/lpp/nominal_max/set")
+	s.False(ok)
+}
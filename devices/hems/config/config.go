@@ -0,0 +1,186 @@
+// Package config loads, validates, migrates and saves the configuration of the HEMS bridge
+//
+// The config is read from JSON or YAML, depending on the file extension. Older
+// config versions are migrated on load, environment variables and command line
+// flags override single values without being written back to the file.
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// CurrentVersion is the config version written by this release
+//
+//   - 0: config without version, a single remote in remoteSki
+//   - 1: list of remotes
+//   - 2: plain failsafe values, secrets encrypted with a key outside the config
+const CurrentVersion = 2
+
+// default values of a new config
+const (
+	DefaultPVMax           = 10000
+	DefaultLPCMax          = 32000 // W, also used for lpc_max 0
+	DefaultMqttPort        = 1883
+	DefaultManualTimeout   = 8 // s
+	DefaultLogLevel        = "info"
+	DefaultLogFormat       = "text"
+	DefaultAuditFile       = "status.log"
+	DefaultAuditMaxSize    = 10 // MB
+	DefaultAuditMaxAge     = 90 // days
+	DefaultAuditMaxBackups = 5
+)
+
+// allowed range of the failsafe duration minimum, 2 to 24 hours
+const (
+	FailsafeDurationMin = 7200  // s
+	FailsafeDurationMax = 86400 // s
+)
+
+type Config struct {
+	Version int     `json:"version" yaml:"version"`
+	Hems    Hems    `json:"hems" yaml:"hems"`
+	Mqtt    Mqtt    `json:"mqtt" yaml:"mqtt"`
+	Secrets Secrets `json:"secrets" yaml:"secrets"`
+	Log     Log     `json:"log" yaml:"log"`
+}
+
+type Hems struct {
+	CertFile       string         `json:"certFile" yaml:"certFile"`
+	KeyFile        string         `json:"keyFile" yaml:"keyFile"`
+	RemoteSKI      string         `json:"remoteSki,omitempty" yaml:"remoteSki,omitempty"` // replaced by Remotes in version 1
+	Remotes        []Remote       `json:"remotes" yaml:"remotes"`
+	Port           int            `json:"port" yaml:"port"`
+	PVMax          int            `json:"pv_max" yaml:"pv_max"`
+	FailsafeValues FailsafeValues `json:"failsafe_values" yaml:"failsafe_values"`
+	SN             string         `json:"serial_number" yaml:"serial_number"`
+	LPCMax         int            `json:"lpc_max" yaml:"lpc_max"`
+	LPPApproval    ApprovalPolicy `json:"lpp_approval" yaml:"lpp_approval"`
+	LPCApproval    ApprovalPolicy `json:"lpc_approval" yaml:"lpc_approval"`
+
+	// encrypted with the serial number, replaced by FailsafeValues in version 2
+	EncryptedFailsafe            string `json:"failsafe,omitempty" yaml:"failsafe,omitempty"`
+	EncryptedFailsafeDuration    string `json:"failsafe_duration,omitempty" yaml:"failsafe_duration,omitempty"`
+	EncryptedLPCFailsafe         string `json:"lpc_failsafe,omitempty" yaml:"lpc_failsafe,omitempty"`
+	EncryptedLPCFailsafeDuration string `json:"lpc_failsafe_duration,omitempty" yaml:"lpc_failsafe_duration,omitempty"`
+}
+
+// FailsafeValues contains the failsafe values set by the remotes
+type FailsafeValues struct {
+	LPP Failsafe `json:"lpp" yaml:"lpp"`
+	LPC Failsafe `json:"lpc" yaml:"lpc"`
+}
+
+// Failsafe contains the failsafe limit and duration of a use case, unset values use the defaults
+type Failsafe struct {
+	Limit    *int `json:"limit,omitempty" yaml:"limit,omitempty"`       // W
+	Duration *int `json:"duration,omitempty" yaml:"duration,omitempty"` // s
+}
+
+// SetLimit stores the failsafe limit in W
+func (f *Failsafe) SetLimit(limit int) {
+	f.Limit = &limit
+}
+
+// SetDuration stores the failsafe duration in s
+func (f *Failsafe) SetDuration(duration int) {
+	f.Duration = &duration
+}
+
+type Mqtt struct {
+	Broker             string `json:"mqttBroker" yaml:"mqttBroker"`
+	Port               int    `json:"mqttPort" yaml:"mqttPort"`
+	Username           string `json:"mqttUsername" yaml:"mqttUsername"`
+	Password           string `json:"mqttPassword" yaml:"mqttPassword"`                                         // encrypted on start
+	Scheme             string `json:"mqttScheme,omitempty" yaml:"mqttScheme,omitempty"`                         // tcp, ssl, ws or wss, default tcp
+	Path               string `json:"mqttPath,omitempty" yaml:"mqttPath,omitempty"`                             // WebSocket path, default /mqtt
+	CAFile             string `json:"mqttCaFile,omitempty" yaml:"mqttCaFile,omitempty"`                         // CA bundle, path or PEM
+	CertFile           string `json:"mqttCertFile,omitempty" yaml:"mqttCertFile,omitempty"`                     // client certificate, path or PEM
+	KeyFile            string `json:"mqttKeyFile,omitempty" yaml:"mqttKeyFile,omitempty"`                       // client key, path or PEM
+	InsecureSkipVerify bool   `json:"mqttInsecureSkipVerify,omitempty" yaml:"mqttInsecureSkipVerify,omitempty"` // do not verify the broker certificate
+	ClientID           string `json:"mqttClientId,omitempty" yaml:"mqttClientId,omitempty"`                     // default is a random ID
+	KeepAlive          int    `json:"mqttKeepAlive,omitempty" yaml:"mqttKeepAlive,omitempty"`                   // s, default 30
+	CleanSession       *bool  `json:"mqttCleanSession,omitempty" yaml:"mqttCleanSession,omitempty"`             // default true
+	QoS                *byte  `json:"mqttQos,omitempty" yaml:"mqttQos,omitempty"`                               // default 1
+}
+
+// Secrets selects the key source for the encrypted values
+type Secrets struct {
+	Backend    string `json:"backend,omitempty" yaml:"backend,omitempty"`       // keyfile, env, secretfile or prompt, default env or secret file if present, key file otherwise
+	KeyFile    string `json:"keyFile,omitempty" yaml:"keyFile,omitempty"`       // default secret.key next to the config
+	Env        string `json:"env,omitempty" yaml:"env,omitempty"`               // default EEBUS2MQTT_SECRET_KEY
+	SecretFile string `json:"secretFile,omitempty" yaml:"secretFile,omitempty"` // default /run/secrets/eebus2mqtt_key
+	Salt       string `json:"salt,omitempty" yaml:"salt,omitempty"`             // set automatically
+}
+
+// Log contains the logging settings
+type Log struct {
+	Level  string            `json:"level,omitempty" yaml:"level,omitempty"`   // error, warn, info, debug or trace, default info
+	Format string            `json:"format,omitempty" yaml:"format,omitempty"` // text or json, default text
+	Levels map[string]string `json:"levels,omitempty" yaml:"levels,omitempty"` // levels of single subsystems: bridge, ship, spine, usecases or mqtt
+
+	// audit log of the state transitions
+	AuditFile       string `json:"audit_file,omitempty" yaml:"audit_file,omitempty"`               // default status.log in the data directory
+	AuditMaxSize    int    `json:"audit_max_size,omitempty" yaml:"audit_max_size,omitempty"`       // MB, the file is rotated before it gets larger, default 10
+	AuditMaxAge     int    `json:"audit_max_age,omitempty" yaml:"audit_max_age,omitempty"`         // days after which the file is rotated and rotated files are deleted, default 90
+	AuditMaxBackups int    `json:"audit_max_backups,omitempty" yaml:"audit_max_backups,omitempty"` // number of rotated files to keep, default 5
+}
+
+// Remote is a remote EEBUS service the bridge is paired with
+type Remote struct {
+	SKI      string `json:"ski" yaml:"ski"`
+	ShipID   string `json:"shipId,omitempty" yaml:"shipId,omitempty"`     // set automatically after the first connection
+	Name     string `json:"name,omitempty" yaml:"name,omitempty"`         // MQTT namespace, default is the SKI
+	LocalSKI string `json:"localSki,omitempty" yaml:"localSki,omitempty"` // local SKI of the pairing, set automatically
+}
+
+// ApprovalPolicy contains the rules for incoming limits of a use case
+//
+// All values are positive W, also for production limits. An empty policy
+// only denies limits with the wrong sign and active limits without a duration.
+type ApprovalPolicy struct {
+	MinValue          *float64 `json:"min_value,omitempty" yaml:"min_value,omitempty"`                     // W, active limits below are denied
+	MaxValue          *float64 `json:"max_value,omitempty" yaml:"max_value,omitempty"`                     // W, active limits above are denied
+	AllowedSKIs       []string `json:"allowed_skis,omitempty" yaml:"allowed_skis,omitempty"`               // only limits from these remote SKIs are approved, empty = all
+	MaxDuration       int      `json:"max_duration,omitempty" yaml:"max_duration,omitempty"`               // s, active limits running longer are denied, 0 = no maximum
+	AllowZeroDuration bool     `json:"allow_zero_duration,omitempty" yaml:"allow_zero_duration,omitempty"` // approve active limits without a duration
+	Manual            bool     `json:"manual,omitempty" yaml:"manual,omitempty"`                           // ask for approval via MQTT
+	ManualTimeout     int      `json:"manual_timeout,omitempty" yaml:"manual_timeout,omitempty"`           // s to wait for a manual decision, default 8
+	Reason            string   `json:"reason,omitempty" yaml:"reason,omitempty"`                           // reason sent to the remote instead of a rule or the timeout
+}
+
+// New returns a config with the default values
+func New() Config {
+	return Config{
+		Version: CurrentVersion,
+		Hems: Hems{
+			Remotes: []Remote{},
+			PVMax:   DefaultPVMax,
+			LPCMax:  DefaultLPCMax,
+		},
+		Mqtt: Mqtt{
+			Port: DefaultMqttPort,
+		},
+	}
+}
+
+// ManualTimeoutDuration returns the time to wait for a manual decision
+func (p ApprovalPolicy) ManualTimeoutDuration() time.Duration {
+	if p.ManualTimeout <= 0 {
+		return DefaultManualTimeout * time.Second
+	}
+	return time.Duration(p.ManualTimeout) * time.Second
+}
+
+// AllowsSKI checks if a remote SKI is allowed to write limits
+func (p ApprovalPolicy) AllowsSKI(ski string) bool {
+	if len(p.AllowedSKIs) == 0 {
+		return true
+	}
+	for _, allowed := range p.AllowedSKIs {
+		if strings.EqualFold(strings.TrimSpace(allowed), ski) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/enbility/eebus-go/devices/hems/secrets"
+	shiputil "github.com/enbility/ship-go/util"
+)
+
+// a migration updates a config from the version of its index to the next one
+type migration func(c *Config)
+
+var migrations = []migration{
+	migrateRemoteSKI,      // 0 -> 1
+	migrateFailsafeValues, // 1 -> 2
+}
+
+// Migrate updates the config to CurrentVersion
+//
+// returns true if the config was changed, configs of a newer release are rejected
+func Migrate(c *Config) (bool, error) {
+	if c.Version > CurrentVersion {
+		return false, fmt.Errorf("config version %d is newer than the supported version %d", c.Version, CurrentVersion)
+	}
+	if c.Version < 0 {
+		return false, fmt.Errorf("invalid config version %d", c.Version)
+	}
+
+	changed := false
+	for c.Version < CurrentVersion {
+		migrations[c.Version](c)
+		c.Version++
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// move the single remoteSki to the remotes list
+func migrateRemoteSKI(c *Config) {
+	ski := shiputil.NormalizeSKI(c.Hems.RemoteSKI)
+	c.Hems.RemoteSKI = ""
+	if c.Hems.Remotes == nil {
+		c.Hems.Remotes = []Remote{}
+	}
+	if ski == "" || ski == "replacewithremoteski" {
+		return
+	}
+
+	for _, remote := range c.Hems.Remotes {
+		if shiputil.NormalizeSKI(remote.SKI) == ski {
+			return
+		}
+	}
+	c.Hems.Remotes = append(c.Hems.Remotes, Remote{SKI: ski})
+}
+
+// decrypt the failsafe values encrypted with the serial number
+//
+// values that can not be decrypted are dropped and set to the defaults on start
+func migrateFailsafeValues(c *Config) {
+	c.Hems.FailsafeValues.LPP = legacyFailsafe(c.Hems.SN, c.Hems.EncryptedFailsafe, c.Hems.EncryptedFailsafeDuration)
+	c.Hems.FailsafeValues.LPC = legacyFailsafe(c.Hems.SN, c.Hems.EncryptedLPCFailsafe, c.Hems.EncryptedLPCFailsafeDuration)
+
+	c.Hems.EncryptedFailsafe = ""
+	c.Hems.EncryptedFailsafeDuration = ""
+	c.Hems.EncryptedLPCFailsafe = ""
+	c.Hems.EncryptedLPCFailsafeDuration = ""
+}
+
+func legacyFailsafe(serial, limit, duration string) Failsafe {
+	var f Failsafe
+
+	if value, ok := legacyInt(serial, limit); ok {
+		f.SetLimit(value)
+	}
+	if value, ok := legacyInt(serial, duration); ok {
+		f.SetDuration(value)
+	}
+
+	return f
+}
+
+func legacyInt(serial, value string) (int, bool) {
+	if value == "" {
+		return 0, false
+	}
+	plain, err := secrets.DecryptLegacy(serial, value)
+	if err != nil {
+		return 0, false
+	}
+	number, err := strconv.Atoi(plain)
+	return number, err == nil
+}
@@ -0,0 +1,60 @@
+package config
+
+func (s *ConfigSuite) Test_Migrate_RemoteSKI() {
+	c := Config{Hems: Hems{RemoteSKI: "0123 4567 89AB CDEF 0123 4567 89ab cdef 0123 4567"}}
+
+	changed, err := Migrate(&c)
+	s.Nil(err)
+	s.True(changed)
+	s.Equal(CurrentVersion, c.Version)
+	s.Equal("", c.Hems.RemoteSKI)
+	s.Equal([]Remote{{SKI: testSKI}}, c.Hems.Remotes)
+}
+
+func (s *ConfigSuite) Test_Migrate_Placeholder() {
+	c := Config{Hems: Hems{RemoteSKI: "replace-with-remote-ski"}}
+
+	changed, err := Migrate(&c)
+	s.Nil(err)
+	s.True(changed)
+	s.Equal([]Remote{}, c.Hems.Remotes)
+}
+
+func (s *ConfigSuite) Test_Migrate_FailsafeValues() {
+	c := Config{Version: 1, Hems: Hems{
+		SN:                           "0123456789",
+		EncryptedFailsafe:            "k+dMqlXRZbxsibr4xxUl4rmJdT5bEHsUxqw3/x0A4v8=",
+		EncryptedFailsafeDuration:    "8blvrS8Qh2OgzSIe8Yb0NzrsbQxwD/wOwN71gfaqW0yU",
+		EncryptedLPCFailsafe:         "invalid",
+		EncryptedLPCFailsafeDuration: "",
+	}}
+
+	changed, err := Migrate(&c)
+	s.Nil(err)
+	s.True(changed)
+	s.Equal(3000, *c.Hems.FailsafeValues.LPP.Limit)
+	s.Equal(86400, *c.Hems.FailsafeValues.LPP.Duration)
+	s.Nil(c.Hems.FailsafeValues.LPC.Limit)
+	s.Nil(c.Hems.FailsafeValues.LPC.Duration)
+	s.Equal("", c.Hems.EncryptedFailsafe)
+	s.Equal("", c.Hems.EncryptedLPCFailsafe)
+}
+
+func (s *ConfigSuite) Test_Migrate_Current() {
+	c := validConfig()
+
+	changed, err := Migrate(&c)
+	s.Nil(err)
+	s.False(changed)
+}
+
+func (s *ConfigSuite) Test_Migrate_Newer() {
+	c := Config{Version: CurrentVersion + 1}
+
+	_, err := Migrate(&c)
+	s.ErrorContains(err, "newer than the supported version")
+}
+
+func (s *ConfigSuite) Test_Migrations() {
+	s.Len(migrations, CurrentVersion)
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix is the prefix of the environment variables overriding config values
+const EnvPrefix = "EEBUS2MQTT_"
+
+// PathKey is the override key of the config file path
+const PathKey = "config"
+
+// DataDirKey is the override key of the data directory
+//
+// The data directory contains the config file if no path is given, the state
+// log and the secret key file.
+const DataDirKey = "data-dir"
+
+// Overrides contains config values by flag name, e.g. mqtt-broker
+//
+// The environment variable of a value is EnvPrefix followed by the upper case
+// name with underscores, e.g. EEBUS2MQTT_MQTT_BROKER.
+type Overrides map[string]string
+
+// a config value that can be overridden
+type override struct {
+	name  string
+	usage string
+	// return a pointer to the value in the config, *string, *int, *bool or *byte
+	field func(c *Config) any
+}
+
+var overrideFields = []override{
+	{"hems-port", "EEBUS port", func(c *Config) any { return &c.Hems.Port }},
+	{"pv-max", "nominal max PV production in W", func(c *Config) any { return &c.Hems.PVMax }},
+	{"lpc-max", "nominal max consumption in W", func(c *Config) any { return &c.Hems.LPCMax }},
+	{"mqtt-broker", "MQTT broker host name or URL", func(c *Config) any { return &c.Mqtt.Broker }},
+	{"mqtt-port", "MQTT broker port", func(c *Config) any { return &c.Mqtt.Port }},
+	{"mqtt-username", "MQTT user name", func(c *Config) any { return &c.Mqtt.Username }},
+	{"mqtt-password", "MQTT password", func(c *Config) any { return &c.Mqtt.Password }},
+	{"mqtt-scheme", "MQTT scheme tcp, ssl, ws or wss", func(c *Config) any { return &c.Mqtt.Scheme }},
+	{"mqtt-path", "MQTT WebSocket path", func(c *Config) any { return &c.Mqtt.Path }},
+	{"mqtt-ca-file", "MQTT CA bundle, path or PEM", func(c *Config) any { return &c.Mqtt.CAFile }},
+	{"mqtt-cert-file", "MQTT client certificate, path or PEM", func(c *Config) any { return &c.Mqtt.CertFile }},
+	{"mqtt-key-file", "MQTT client key, path or PEM", func(c *Config) any { return &c.Mqtt.KeyFile }},
+	{"mqtt-insecure-skip-verify", "do not verify the MQTT broker certificate", func(c *Config) any { return &c.Mqtt.InsecureSkipVerify }},
+	{"mqtt-client-id", "MQTT client ID", func(c *Config) any { return &c.Mqtt.ClientID }},
+	{"mqtt-keepalive", "MQTT keepalive in s", func(c *Config) any { return &c.Mqtt.KeepAlive }},
+	{"secret-backend", "secret key source keyfile, env, secretfile or prompt", func(c *Config) any { return &c.Secrets.Backend }},
+	{"secret-key-file", "secret key file", func(c *Config) any { return &c.Secrets.KeyFile }},
+	{"log-level", "log level error, warn, info, debug or trace", func(c *Config) any { return &c.Log.Level }},
+	{"log-format", "log format text or json", func(c *Config) any { return &c.Log.Format }},
+	{"mqtt-qos", "MQTT QoS 0, 1 or 2", func(c *Config) any {
+		// the QoS is optional, never write through a pointer shared with another config
+		c.Mqtt.QoS = new(byte)
+		return c.Mqtt.QoS
+	}},
+}
+
+// return the environment variable of an override
+func envName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// Env returns the overrides set in the environment
+//
+// The config file path is read from EEBUS2MQTT_CONFIG or CONFIG_FILE, the data
+// directory from EEBUS2MQTT_DATA_DIR.
+func Env() Overrides {
+	o := Overrides{}
+
+	for _, item := range overrideFields {
+		if value, ok := os.LookupEnv(envName(item.name)); ok {
+			o[item.name] = value
+		}
+	}
+
+	for _, name := range []string{"CONFIG_FILE", envName(PathKey)} {
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			o[PathKey] = value
+		}
+	}
+	if value, ok := os.LookupEnv(envName(DataDirKey)); ok && value != "" {
+		o[DataDirKey] = value
+	}
+
+	return o
+}
+
+// Flags registers a flag for every override, the config file path and the data directory on fs
+//
+// The returned overrides are filled when fs is parsed.
+func Flags(fs *flag.FlagSet) Overrides {
+	o := Overrides{}
+
+	fs.Func(PathKey, "config file, .json, .yaml or .yml (env "+envName(PathKey)+")", func(value string) error {
+		o[PathKey] = value
+		return nil
+	})
+	fs.Func(DataDirKey, "data directory, default is the working directory (env "+envName(DataDirKey)+")", func(value string) error {
+		o[DataDirKey] = value
+		return nil
+	})
+
+	for _, item := range overrideFields {
+		fs.Func(item.name, item.usage+" (env "+envName(item.name)+")", func(value string) error {
+			// reject invalid values while parsing
+			var scratch Config
+			if err := set(item.field(&scratch), value); err != nil {
+				return err
+			}
+			o[item.name] = value
+			return nil
+		})
+	}
+
+	return o
+}
+
+// merge the overrides, later ones take precedence
+func merge(overrides []Overrides) Overrides {
+	merged := Overrides{}
+	for _, o := range overrides {
+		for name, value := range o {
+			merged[name] = value
+		}
+	}
+	return merged
+}
+
+// apply the overrides to the config
+func (o Overrides) apply(c *Config) error {
+	for _, item := range overrideFields {
+		value, ok := o[item.name]
+		if !ok {
+			continue
+		}
+		if err := set(item.field(c), value); err != nil {
+			return fmt.Errorf("override %s (%s): %w", item.name, envName(item.name), err)
+		}
+	}
+	return nil
+}
+
+// copy the overridden values from src to dst
+func (o Overrides) restore(dst, src *Config) {
+	for _, item := range overrideFields {
+		if _, ok := o[item.name]; !ok {
+			continue
+		}
+		switch field := item.field(dst).(type) {
+		case *string:
+			*field = *item.field(src).(*string)
+		case *int:
+			*field = *item.field(src).(*int)
+		case *bool:
+			*field = *item.field(src).(*bool)
+		case *byte:
+			if src.Mqtt.QoS == nil {
+				dst.Mqtt.QoS = nil
+			} else {
+				*field = *src.Mqtt.QoS
+			}
+		}
+	}
+}
+
+// parse a value into the config field
+func set(field any, value string) error {
+	switch field := field.(type) {
+	case *string:
+		*field = value
+	case *int:
+		number, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		*field = number
+	case *bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		*field = b
+	case *byte:
+		number, err := strconv.ParseUint(strings.TrimSpace(value), 10, 8)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		*field = byte(number)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"flag"
+	"io"
+)
+
+func (s *ConfigSuite) Test_Env() {
+	s.T().Setenv("EEBUS2MQTT_MQTT_BROKER", "env-broker")
+	s.T().Setenv("EEBUS2MQTT_MQTT_QOS", "2")
+	s.T().Setenv("CONFIG_FILE", "legacy.json")
+
+	o := Env()
+	s.Equal("env-broker", o["mqtt-broker"])
+	s.Equal("2", o["mqtt-qos"])
+	s.Equal("legacy.json", o[PathKey])
+
+	s.T().Setenv("EEBUS2MQTT_CONFIG", "config.yaml")
+	s.Equal("config.yaml", Env()[PathKey])
+
+	s.T().Setenv("EEBUS2MQTT_DATA_DIR", "/data")
+	s.T().Setenv("EEBUS2MQTT_LOG_LEVEL", "debug")
+	o = Env()
+	s.Equal("/data", o[DataDirKey])
+	s.Equal("debug", o["log-level"])
+}
+
+func (s *ConfigSuite) Test_Flags() {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	o := Flags(fs)
+
+	s.Nil(fs.Parse([]string{"-config", "my.json", "-data-dir", "data", "-mqtt-port", "8883", "-mqtt-insecure-skip-verify", "true"}))
+	s.Equal(Overrides{PathKey: "my.json", DataDirKey: "data", "mqtt-port": "8883", "mqtt-insecure-skip-verify": "true"}, o)
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	Flags(fs)
+	s.NotNil(fs.Parse([]string{"-mqtt-port", "abc"}))
+}
+
+func (s *ConfigSuite) Test_Overrides_Restore() {
+	file := validConfig()
+	c := file
+
+	o := Overrides{"mqtt-broker": "override", "mqtt-qos": "0", "hems-port": "4715"}
+	s.Nil(o.apply(&c))
+	s.Equal("override", c.Mqtt.Broker)
+	s.Equal(byte(0), *c.Mqtt.QoS)
+	s.Equal(4715, c.Hems.Port)
+	s.Nil(file.Mqtt.QoS)
+
+	c.Mqtt.Username = "changed"
+	o.restore(&c, &file)
+	s.Equal("broker", c.Mqtt.Broker)
+	s.Nil(c.Mqtt.QoS)
+	s.Equal(0, c.Hems.Port)
+	s.Equal("changed", c.Mqtt.Username)
+
+	s.ErrorContains(Overrides{"pv-max": "many"}.apply(&c), "EEBUS2MQTT_PV_MAX")
+}
@@ -0,0 +1,293 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDataDir is the data directory if none is given
+const DefaultDataDir = "."
+
+// file names searched if no config file is given
+var defaultFiles = []string{"config.json", "config.yaml", "config.yml"}
+
+// Store reads and writes the config file
+//
+// Values set by overrides are kept in memory only, Save writes the values
+// of the file for them.
+type Store struct {
+	path      string
+	overrides Overrides
+	created   bool
+
+	mux  sync.Mutex
+	file Config // the values in the file
+}
+
+// ResolvePath returns the config file in the data directory
+//
+// The first existing default file is used, config.json if there is none.
+func ResolvePath(dataDir string) string {
+	for _, name := range defaultFiles {
+		path := filepath.Join(dataDir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dataDir, defaultFiles[0])
+}
+
+// FilePath returns the config file used by Load with the overrides
+//
+// The file is given by the PathKey override or found by ResolvePath in the
+// data directory of the DataDirKey override.
+func FilePath(overrides ...Overrides) string {
+	merged := merge(overrides)
+
+	if path := merged[PathKey]; path != "" {
+		return path
+	}
+	return ResolvePath(dataDir(merged))
+}
+
+// return the data directory of the overrides
+func dataDir(o Overrides) string {
+	if dir := o[DataDirKey]; dir != "" {
+		return dir
+	}
+	return DefaultDataDir
+}
+
+// Load reads, migrates and validates the config
+//
+// The file is selected by FilePath. A missing file and its directory are
+// created with the defaults, a migrated config is written back. The
+// overrides are applied in order, later ones take precedence. On validation
+// errors the config and the store are returned with the error.
+func Load(overrides ...Overrides) (Config, *Store, error) {
+	merged := merge(overrides)
+
+	path := FilePath(merged)
+	s := &Store{path: path, overrides: merged}
+
+	c, err := s.read()
+	if err != nil {
+		return c, nil, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	migrated, err := Migrate(&c)
+	if err != nil {
+		return c, nil, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	s.file = c
+	if s.created {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return c, nil, fmt.Errorf("config %s: %w", path, err)
+		}
+	}
+	if s.created || migrated {
+		if err := s.write(c); err != nil {
+			return c, nil, fmt.Errorf("config %s: %w", path, err)
+		}
+	}
+
+	if err := merged.apply(&c); err != nil {
+		return c, s, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return c, s, fmt.Errorf("invalid config %s:\n%w", path, err)
+	}
+
+	return c, s, nil
+}
+
+// Path returns the config file path
+func (s *Store) Path() string {
+	return s.path
+}
+
+// DataDir returns the data directory
+//
+// Without a DataDirKey override it is the directory of the config file.
+func (s *Store) DataDir() string {
+	if dir := s.overrides[DataDirKey]; dir != "" {
+		return dir
+	}
+	return filepath.Dir(s.path)
+}
+
+// Created returns true if the file did not exist and was created by Load
+func (s *Store) Created() bool {
+	return s.created
+}
+
+// Save writes the config to the file
+//
+// The file is replaced atomically, concurrent saves are serialized.
+func (s *Store) Save(c Config) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	out := c
+	s.overrides.restore(&out, &s.file)
+
+	if err := s.write(out); err != nil {
+		return fmt.Errorf("config %s: %w", s.path, err)
+	}
+	s.file = out
+
+	return nil
+}
+
+// return true for YAML files
+func isYAML(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// read the file, a missing file returns the defaults
+func (s *Store) read() (Config, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.created = true
+		return New(), nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+
+	var c Config
+	if isYAML(s.path) {
+		err = decodeYAML(data, &c)
+	} else {
+		err = decodeJSON(data, &c)
+	}
+	return c, err
+}
+
+func decodeYAML(data []byte, c *Config) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(c); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("file is empty, delete it to create a new config")
+		}
+		return err
+	}
+	return nil
+}
+
+func decodeJSON(data []byte, c *Config) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return errors.New("file is empty, delete it to create a new config")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(c)
+	if err == nil {
+		return nil
+	}
+
+	// add the line of the error
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return err
+	}
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return fmt.Errorf("line %d: %w", 1+bytes.Count(data[:offset], []byte("\n")), err)
+}
+
+// Encode returns the config in the format of the file
+func (s *Store) Encode(c Config) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if isYAML(s.path) {
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(c); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(c); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// write the config to a temporary file and rename it
+func (s *Store) write(c Config) error {
+	data, err := s.Encode(c)
+	if err != nil {
+		return err
+	}
+
+	// the file contains the private key
+	mode := os.FileMode(0600)
+	if info, err := os.Stat(s.path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), "."+filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		// a file mounted into a container can not be replaced, write it in place
+		if writeErr := os.WriteFile(s.path, data, mode); writeErr != nil {
+			return errors.Join(err, writeErr)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (s *ConfigSuite) Test_ResolvePath() {
+	s.Equal("config.json", ResolvePath("."))
+
+	data := filepath.Join(s.dir, "data")
+	s.Equal(filepath.Join(data, "config.json"), ResolvePath(data))
+
+	s.Require().NoError(os.Mkdir(data, 0755))
+	s.writeFile(filepath.Join("data", "config.yaml"), "")
+	s.Equal(filepath.Join(data, "config.yaml"), ResolvePath(data))
+
+	s.writeFile("config.yml", "")
+	s.Equal("config.yml", ResolvePath("."))
+}
+
+func (s *ConfigSuite) Test_FilePath() {
+	s.Equal("config.json", FilePath())
+	s.Equal(filepath.Join("data", "config.json"), FilePath(Overrides{DataDirKey: "data"}))
+	s.Equal("other.yaml", FilePath(Overrides{DataDirKey: "data"}, Overrides{PathKey: "other.yaml"}))
+}
+
+func (s *ConfigSuite) Test_Load_DataDir() {
+	data := filepath.Join(s.dir, "data")
+
+	_, store, err := Load(Overrides{DataDirKey: data, "mqtt-broker": "broker"})
+	s.Nil(err)
+	s.True(store.Created())
+	s.Equal(filepath.Join(data, "config.json"), store.Path())
+	s.Equal(data, store.DataDir())
+	s.FileExists(store.Path())
+
+	_, store, err = Load(Overrides{PathKey: filepath.Join(data, "config.json"), "mqtt-broker": "broker"})
+	s.Nil(err)
+	s.False(store.Created())
+	s.Equal(data, store.DataDir())
+}
+
+func (s *ConfigSuite) Test_Load_Create() {
+	c, store, err := Load()
+	s.ErrorContains(err, "mqtt.mqttBroker: is required")
+	s.NotNil(store)
+	s.True(store.Created())
+	s.Equal(New(), c)
+
+	info, err := os.Stat("config.json")
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	c, store, err = Load(Overrides{"mqtt-broker": "broker"})
+	s.Nil(err)
+	s.False(store.Created())
+	s.Equal("broker", c.Mqtt.Broker)
+}
+
+func (s *ConfigSuite) Test_Load_Migrate() {
+	path := s.writeFile("old.json", `{
+  "hems": {"remoteSki": "`+testSKI+`", "serial_number": "0123456789"},
+  "mqtt": {"mqttBroker": "broker"}
+}`)
+
+	c, _, err := Load(Overrides{PathKey: path})
+	s.Nil(err)
+	s.Equal(CurrentVersion, c.Version)
+	s.Equal([]Remote{{SKI: testSKI}}, c.Hems.Remotes)
+
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Contains(string(data), fmt.Sprintf(`"version": %d`, CurrentVersion))
+	s.NotContains(string(data), "remoteSki")
+}
+
+func (s *ConfigSuite) Test_Load_YAML() {
+	path := s.writeFile("config.yaml", `version: 2
+hems:
+  serial_number: "0123456789"
+  remotes:
+    - ski: `+testSKI+`
+      name: wallbox
+  failsafe_values:
+    lpc:
+      limit: 4200
+      duration: 7200
+  lpc_approval:
+    max_value: 4200
+mqtt:
+  mqttBroker: broker
+  mqttQos: 2
+`)
+
+	c, _, err := Load(Overrides{PathKey: path})
+	s.Nil(err)
+	s.Equal("wallbox", c.Hems.Remotes[0].Name)
+	s.Equal(4200.0, *c.Hems.LPCApproval.MaxValue)
+	s.Equal(4200, *c.Hems.FailsafeValues.LPC.Limit)
+	s.Nil(c.Hems.FailsafeValues.LPP.Limit)
+	s.Equal(byte(2), *c.Mqtt.QoS)
+}
+
+func (s *ConfigSuite) Test_Load_Errors() {
+	path := s.writeFile("syntax.json", "{\n  \"hems\": {\n    \"port\": 4713,\n  }\n}")
+	_, _, err := Load(Overrides{PathKey: path})
+	s.ErrorContains(err, "line 4")
+
+	path = s.writeFile("type.json", "{\n  \"mqtt\": {\n    \"mqttPort\": \"1883\"\n  }\n}")
+	_, _, err = Load(Overrides{PathKey: path})
+	s.ErrorContains(err, "line 3")
+
+	path = s.writeFile("unknown.json", `{"mqtt": {"mqttBrocker": "broker"}}`)
+	_, _, err = Load(Overrides{PathKey: path})
+	s.ErrorContains(err, `unknown field "mqttBrocker"`)
+
+	path = s.writeFile("unknown.yaml", "mqtt:\n  mqttBrocker: broker\n")
+	_, _, err = Load(Overrides{PathKey: path})
+	s.ErrorContains(err, "mqttBrocker")
+
+	path = s.writeFile("empty.json", "")
+	_, _, err = Load(Overrides{PathKey: path})
+	s.ErrorContains(err, "file is empty")
+}
+
+func (s *ConfigSuite) Test_Save() {
+	path := filepath.Join(s.dir, "config.yaml")
+
+	_, store, err := Load(Overrides{PathKey: path}, Overrides{"mqtt-broker": "override"})
+	s.Nil(err)
+
+	c := validConfig()
+	c.Mqtt.Broker = "override"
+	c.Mqtt.Username = "user"
+	s.Nil(store.Save(c))
+
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.True(strings.HasPrefix(string(data), fmt.Sprintf("version: %d\n", CurrentVersion)))
+	s.Contains(string(data), "mqttUsername: user")
+	s.NotContains(string(data), "override")
+
+	// no temporary files are left
+	entries, err := os.ReadDir(s.dir)
+	s.Require().NoError(err)
+	s.Len(entries, 1)
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const testSKI = "0123456789abcdef0123456789abcdef01234567"
+
+func TestConfigSuite(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
+}
+
+type ConfigSuite struct {
+	suite.Suite
+
+	dir string
+}
+
+func (s *ConfigSuite) BeforeTest(suiteName, testName string) {
+	s.dir = s.T().TempDir()
+	s.T().Chdir(s.dir)
+}
+
+// write a config file in the test directory
+func (s *ConfigSuite) writeFile(name, content string) string {
+	path := filepath.Join(s.dir, name)
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+// return a valid config
+func validConfig() Config {
+	c := New()
+	c.Mqtt.Broker = "broker"
+	c.Hems.SN = "0123456789"
+	c.Hems.Remotes = []Remote{{SKI: testSKI}}
+	return c
+}
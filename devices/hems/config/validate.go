@@ -0,0 +1,231 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/enbility/eebus-go/devices/hems/logger"
+	"github.com/enbility/eebus-go/devices/hems/secrets"
+	shiputil "github.com/enbility/ship-go/util"
+)
+
+var (
+	skiPattern    = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	serialPattern = regexp.MustCompile(`^\d{10}$`)
+)
+
+// FieldError describes an invalid config value
+//
+// Field is the path of the value in the config file, e.g. hems.remotes[0].ski
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// collects the errors of a validation
+type validator []error
+
+func (v *validator) add(field, format string, args ...any) {
+	*v = append(*v, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) port(field string, port int) {
+	if port < 0 || port > 65535 {
+		v.add(field, "%d is not a valid port, use 1 to 65535 or 0 for the default", port)
+	}
+}
+
+func (v *validator) notNegative(field string, value int) {
+	if value < 0 {
+		v.add(field, "must not be negative")
+	}
+}
+
+func (v *validator) ski(field, ski string) {
+	if !skiPattern.MatchString(shiputil.NormalizeSKI(ski)) {
+		v.add(field, "%q is not a valid SKI, 40 hex characters are required", ski)
+	}
+}
+
+// Validate checks all values and returns one FieldError per invalid value
+func (c Config) Validate() error {
+	var v validator
+
+	if c.Version != CurrentVersion {
+		v.add("version", "is %d, migrate the config to version %d", c.Version, CurrentVersion)
+	}
+
+	c.Hems.validate(&v, "hems")
+	c.Mqtt.validate(&v, "mqtt")
+	c.Secrets.validate(&v, "secrets")
+	c.Log.validate(&v, "log")
+
+	return errors.Join(v...)
+}
+
+func (h Hems) validate(v *validator, field string) {
+	v.port(field+".port", h.Port)
+	v.notNegative(field+".pv_max", h.PVMax)
+	v.notNegative(field+".lpc_max", h.LPCMax)
+
+	if h.SN != "" && !serialPattern.MatchString(h.SN) {
+		v.add(field+".serial_number", "must be exactly 10 digits")
+	}
+	if (h.CertFile == "") != (h.KeyFile == "") {
+		v.add(field+".certFile", "certificate and key have to be set both, remove both to create a new certificate")
+	}
+
+	skis := make(map[string]bool)
+	for _, remote := range h.Remotes {
+		skis[shiputil.NormalizeSKI(remote.SKI)] = true
+	}
+
+	seen := make(map[string]bool)
+	names := make(map[string]bool)
+	for i, remote := range h.Remotes {
+		item := fmt.Sprintf("%s.remotes[%d]", field, i)
+		remote.validate(v, item)
+
+		ski := shiputil.NormalizeSKI(remote.SKI)
+		if seen[ski] {
+			v.add(item+".ski", "SKI %s is configured twice", ski)
+		}
+		seen[ski] = true
+
+		// the name replaces the SKI in the MQTT topics
+		if remote.Name != "" {
+			if names[remote.Name] {
+				v.add(item+".name", "name %q is used twice", remote.Name)
+			} else if remote.Name != ski && skis[remote.Name] {
+				v.add(item+".name", "name %q is the SKI of another remote", remote.Name)
+			}
+			names[remote.Name] = true
+		}
+	}
+
+	lpcMax := h.LPCMax
+	if lpcMax == 0 {
+		lpcMax = DefaultLPCMax
+	}
+	h.FailsafeValues.LPP.validate(v, field+".failsafe_values.lpp", "pv_max", h.PVMax)
+	h.FailsafeValues.LPC.validate(v, field+".failsafe_values.lpc", "lpc_max", lpcMax)
+
+	h.LPPApproval.validate(v, field+".lpp_approval")
+	h.LPCApproval.validate(v, field+".lpc_approval")
+}
+
+// Validate checks the SKI and the name of a remote
+func (r Remote) Validate() error {
+	var v validator
+	r.validate(&v, "remote")
+	return errors.Join(v...)
+}
+
+func (r Remote) validate(v *validator, field string) {
+	v.ski(field+".ski", r.SKI)
+	if strings.ContainsAny(r.Name, "/+# ") {
+		v.add(field+".name", "%q must not contain /, +, # or spaces", r.Name)
+	}
+}
+
+// the failsafe limit must not exceed the nominal max of the use case
+func (f Failsafe) validate(v *validator, field, maxField string, nominalMax int) {
+	if f.Limit != nil {
+		switch {
+		case *f.Limit < 0:
+			v.add(field+".limit", "must not be negative")
+		case *f.Limit > nominalMax:
+			v.add(field+".limit", "%d W is above %s of %d W", *f.Limit, maxField, nominalMax)
+		}
+	}
+	if f.Duration != nil && (*f.Duration < FailsafeDurationMin || *f.Duration > FailsafeDurationMax) {
+		v.add(field+".duration", "%d s is outside of %d to %d s", *f.Duration, FailsafeDurationMin, FailsafeDurationMax)
+	}
+}
+
+func (p ApprovalPolicy) validate(v *validator, field string) {
+	if p.MinValue != nil && *p.MinValue < 0 {
+		v.add(field+".min_value", "must not be negative, production limits are positive W as well")
+	}
+	if p.MaxValue != nil && *p.MaxValue < 0 {
+		v.add(field+".max_value", "must not be negative, production limits are positive W as well")
+	}
+	if p.MinValue != nil && p.MaxValue != nil && *p.MinValue > *p.MaxValue {
+		v.add(field+".min_value", "%.f is above max_value %.f", *p.MinValue, *p.MaxValue)
+	}
+	v.notNegative(field+".max_duration", p.MaxDuration)
+	v.notNegative(field+".manual_timeout", p.ManualTimeout)
+	for i, ski := range p.AllowedSKIs {
+		v.ski(fmt.Sprintf("%s.allowed_skis[%d]", field, i), ski)
+	}
+}
+
+func (m Mqtt) validate(v *validator, field string) {
+	switch {
+	case m.Broker == "":
+		v.add(field+".mqttBroker", "is required")
+	case strings.Contains(m.Broker, "://"):
+		if _, err := url.Parse(m.Broker); err != nil {
+			v.add(field+".mqttBroker", "is not a valid URL: %s", err)
+		}
+	}
+
+	v.port(field+".mqttPort", m.Port)
+
+	switch strings.ToLower(m.Scheme) {
+	case "", "tcp", "mqtt", "ssl", "tls", "mqtts", "ws", "wss":
+	default:
+		v.add(field+".mqttScheme", "%q is not supported, use tcp, ssl, ws or wss", m.Scheme)
+	}
+
+	if (m.CertFile == "") != (m.KeyFile == "") {
+		v.add(field+".mqttCertFile", "client certificate and key have to be set both")
+	}
+	v.notNegative(field+".mqttKeepAlive", m.KeepAlive)
+	if m.QoS != nil && *m.QoS > 2 {
+		v.add(field+".mqttQos", "%d is not a valid QoS, use 0, 1 or 2", *m.QoS)
+	}
+}
+
+func (s Secrets) validate(v *validator, field string) {
+	switch s.Backend {
+	case "", secrets.BackendKeyFile, secrets.BackendEnv, secrets.BackendSecretFile, secrets.BackendPrompt:
+	default:
+		v.add(field+".backend", "%q is not supported, use %s, %s, %s or %s", s.Backend,
+			secrets.BackendKeyFile, secrets.BackendEnv, secrets.BackendSecretFile, secrets.BackendPrompt)
+	}
+}
+
+func (l Log) validate(v *validator, field string) {
+	if l.Level != "" {
+		if _, err := logger.ParseLevel(l.Level); err != nil {
+			v.add(field+".level", "%s", err)
+		}
+	}
+	switch l.Format {
+	case "", logger.FormatText, logger.FormatJSON:
+	default:
+		v.add(field+".format", "%q is not supported, use %s or %s", l.Format, logger.FormatText, logger.FormatJSON)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(l.Levels)) {
+		if _, err := logger.ParseSubsystem(name); err != nil {
+			v.add(field+".levels."+name, "%s", err)
+		} else if _, err := logger.ParseLevel(l.Levels[name]); err != nil {
+			v.add(field+".levels."+name, "%s", err)
+		}
+	}
+
+	v.notNegative(field+".audit_max_size", l.AuditMaxSize)
+	v.notNegative(field+".audit_max_age", l.AuditMaxAge)
+	v.notNegative(field+".audit_max_backups", l.AuditMaxBackups)
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"errors"
+)
+
+func (s *ConfigSuite) Test_Validate() {
+	s.Nil(validConfig().Validate())
+
+	c := validConfig()
+	c.Version = 0
+	c.Hems.Port = 70000
+	c.Hems.SN = "123"
+	c.Hems.Remotes = append(c.Hems.Remotes, Remote{SKI: testSKI}, Remote{SKI: "invalid", Name: "a/b"})
+	min, max := 5000.0, 1000.0
+	c.Hems.LPPApproval = ApprovalPolicy{MinValue: &min, MaxValue: &max, ManualTimeout: -1}
+	qos := byte(3)
+	c.Mqtt = Mqtt{Scheme: "http", CertFile: "cert.pem", QoS: &qos}
+	c.Hems.FailsafeValues.LPC.SetDuration(-1)
+	c.Secrets.Backend = "vault"
+	c.Log = Log{Level: "verbose", Format: "xml", Levels: map[string]string{"ship": "debug", "modbus": "debug", "mqtt": "loud"}, AuditMaxSize: -1}
+
+	err := c.Validate()
+	s.NotNil(err)
+
+	var fields []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var fieldErr *FieldError
+		s.True(errors.As(e, &fieldErr))
+		fields = append(fields, fieldErr.Field)
+	}
+	s.Equal([]string{
+		"version",
+		"hems.port",
+		"hems.serial_number",
+		"hems.remotes[1].ski",
+		"hems.remotes[2].ski",
+		"hems.remotes[2].name",
+		"hems.failsafe_values.lpc.duration",
+		"hems.lpp_approval.min_value",
+		"hems.lpp_approval.manual_timeout",
+		"mqtt.mqttBroker",
+		"mqtt.mqttScheme",
+		"mqtt.mqttCertFile",
+		"mqtt.mqttQos",
+		"secrets.backend",
+		"log.level",
+		"log.format",
+		"log.levels.modbus",
+		"log.levels.mqtt",
+		"log.audit_max_size",
+	}, fields)
+}
+
+func (s *ConfigSuite) Test_Validate_Failsafe() {
+	tests := []struct {
+		name   string
+		change func(c *Config)
+		err    string
+	}{
+		{name: "defaults", change: func(c *Config) {
+			c.Hems.FailsafeValues.LPP.SetLimit(4200)
+			c.Hems.FailsafeValues.LPP.SetDuration(FailsafeDurationMin)
+			c.Hems.FailsafeValues.LPC.SetLimit(4200)
+			c.Hems.FailsafeValues.LPC.SetDuration(FailsafeDurationMax)
+		}},
+		{name: "negative limit", change: func(c *Config) { c.Hems.FailsafeValues.LPP.SetLimit(-1) },
+			err: "hems.failsafe_values.lpp.limit: must not be negative"},
+		{name: "limit above pv_max", change: func(c *Config) { c.Hems.FailsafeValues.LPP.SetLimit(DefaultPVMax + 1) },
+			err: "hems.failsafe_values.lpp.limit: 10001 W is above pv_max of 10000 W"},
+		{name: "limit above lpc_max", change: func(c *Config) {
+			c.Hems.LPCMax = 11000
+			c.Hems.FailsafeValues.LPC.SetLimit(12000)
+		}, err: "hems.failsafe_values.lpc.limit: 12000 W is above lpc_max of 11000 W"},
+		{name: "limit below the default lpc_max", change: func(c *Config) {
+			c.Hems.LPCMax = 0
+			c.Hems.FailsafeValues.LPC.SetLimit(DefaultLPCMax)
+		}},
+		{name: "duration too short", change: func(c *Config) { c.Hems.FailsafeValues.LPP.SetDuration(7199) },
+			err: "hems.failsafe_values.lpp.duration: 7199 s is outside of 7200 to 86400 s"},
+		{name: "duration too long", change: func(c *Config) { c.Hems.FailsafeValues.LPC.SetDuration(86401) },
+			err: "hems.failsafe_values.lpc.duration: 86401 s is outside of 7200 to 86400 s"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			c := validConfig()
+			test.change(&c)
+			err := c.Validate()
+			if test.err == "" {
+				s.Nil(err)
+				return
+			}
+			s.EqualError(err, test.err)
+		})
+	}
+}
+
+func (s *ConfigSuite) Test_Remote_Validate() {
+	s.Nil(Remote{SKI: testSKI, Name: "wallbox"}.Validate())
+	s.ErrorContains(Remote{SKI: "0123"}.Validate(), "remote.ski")
+
+	for _, name := range []string{"a/b", "a+b", "a#b", "a b"} {
+		s.ErrorContains(Remote{SKI: testSKI, Name: name}.Validate(), "remote.name", name)
+	}
+}
+
+func (s *ConfigSuite) Test_Validate_RemoteNames() {
+	otherSKI := "fedcba9876543210fedcba9876543210fedcba98"
+
+	c := validConfig()
+	c.Hems.Remotes = []Remote{{SKI: testSKI, Name: testSKI}, {SKI: otherSKI, Name: "wallbox"}}
+	s.Nil(c.Validate())
+
+	c.Hems.Remotes = []Remote{{SKI: testSKI, Name: "wallbox"}, {SKI: otherSKI, Name: "wallbox"}}
+	s.EqualError(c.Validate(), `hems.remotes[1].name: name "wallbox" is used twice`)
+
+	// the name would share the MQTT topics of the other remote
+	c.Hems.Remotes = []Remote{{SKI: testSKI}, {SKI: otherSKI, Name: testSKI}}
+	s.EqualError(c.Validate(), `hems.remotes[1].name: name "`+testSKI+`" is the SKI of another remote`)
+}
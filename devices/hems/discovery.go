@@ -0,0 +1,266 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// Home Assistant MQTT discovery
+//
+// All entities are announced as retained messages on
+// homeassistant/<component>/eebus2mqtt_<serial number>/<object id>/config
+// and share the availability topic, which is set to offline by the MQTT Last Will.
+const (
+	discoveryPrefix   = "homeassistant"
+	availabilityTopic = topicPrefix + "status"
+	payloadOnline     = "online"
+	payloadOffline    = "offline"
+)
+
+type discoveryDevice struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer"`
+	Model        string   `json:"model"`
+	SerialNumber string   `json:"serial_number"`
+}
+
+type discoveryConfig struct {
+	Name              string          `json:"name"`
+	UniqueId          string          `json:"unique_id"`
+	StateTopic        string          `json:"state_topic,omitempty"`
+	CommandTopic      string          `json:"command_topic,omitempty"`
+	AttributesTopic   string          `json:"json_attributes_topic,omitempty"`
+	AvailabilityTopic string          `json:"availability_topic"`
+	DeviceClass       string          `json:"device_class,omitempty"`
+	StateClass        string          `json:"state_class,omitempty"`
+	Unit              string          `json:"unit_of_measurement,omitempty"`
+	EntityCategory    string          `json:"entity_category,omitempty"`
+	PayloadOn         string          `json:"payload_on,omitempty"`
+	PayloadOff        string          `json:"payload_off,omitempty"`
+	Min               *float64        `json:"min,omitempty"`
+	Max               *float64        `json:"max,omitempty"`
+	Step              *float64        `json:"step,omitempty"`
+	Mode              string          `json:"mode,omitempty"`
+	Device            discoveryDevice `json:"device"`
+}
+
+// an entity exposed to Home Assistant
+type discoveryEntity struct {
+	component   string // sensor, binary_sensor, number or text
+	objectId    string
+	name        string
+	topic       string // state topic below eebus2mqtt/hems/
+	attributes  string // JSON attributes topic below eebus2mqtt/hems/
+	command     string // command name for number and text entities, see commands
+	deviceClass string
+	stateClass  string
+	unit        string
+	category    string
+	min, max    float64
+	step        float64
+}
+
+func sensor(objectId, name, topic, deviceClass, unit string) discoveryEntity {
+	return discoveryEntity{
+		component:   "sensor",
+		objectId:    objectId,
+		name:        name,
+		topic:       topic,
+		deviceClass: deviceClass,
+		stateClass:  "measurement",
+		unit:        unit,
+	}
+}
+
+func energySensor(objectId, name, topic string) discoveryEntity {
+	e := sensor(objectId, name, topic, "energy", "Wh")
+	e.stateClass = "total_increasing"
+	return e
+}
+
+func textSensor(objectId, name, topic string) discoveryEntity {
+	return discoveryEntity{
+		component: "sensor",
+		objectId:  objectId,
+		name:      name,
+		topic:     topic,
+	}
+}
+
+func diagnosticSensor(objectId, name, topic, deviceClass, unit string) discoveryEntity {
+	e := sensor(objectId, name, topic, deviceClass, unit)
+	e.category = "diagnostic"
+	return e
+}
+
+func binarySensor(objectId, name, topic string) discoveryEntity {
+	return discoveryEntity{
+		component: "binary_sensor",
+		objectId:  objectId,
+		name:      name,
+		topic:     topic,
+	}
+}
+
+func number(objectId, name, topic, command, deviceClass, unit string, min, max, step float64) discoveryEntity {
+	return discoveryEntity{
+		component:   "number",
+		objectId:    objectId,
+		name:        name,
+		topic:       topic,
+		command:     command,
+		deviceClass: deviceClass,
+		unit:        unit,
+		category:    "config",
+		min:         min,
+		max:         max,
+		step:        step,
+	}
+}
+
+// a text input publishing to a command topic
+func text(objectId, name, command string) discoveryEntity {
+	return discoveryEntity{
+		component: "text",
+		objectId:  objectId,
+		name:      name,
+		command:   command,
+		category:  "config",
+	}
+}
+
+// all entities the bridge exposes
+var discoveryEntities = []discoveryEntity{
+	// LPP
+	sensor("lpp_allowed_production", "LPP allowed production", "lpp/allowed_production", "power", "W"),
+	textSensor("lpp_state", "LPP state", "lpp/state"),
+	diagnosticSensor("lpp_last_heartbeat", "LPP last heartbeat", "lpp/last_heartbeat", "duration", "s"),
+	diagnosticSensor("lpp_limit_countdown", "LPP limit countdown", "lpp/LimitCountdown", "duration", "s"),
+	diagnosticSensor("lpp_failsafe_countdown", "LPP failsafe countdown", "lpp/FailsafeCountdown", "duration", "s"),
+	binarySensor("lpp_limit_active", "LPP limit active", "lpp/limit_activ"),
+	number("lpp_nominal_max", "LPP nominal max", "lpp/NominalMax", "lpp/nominal_max", "power", "W", 0, 100000, 1),
+	number("lpp_failsafe_limit", "LPP failsafe limit", "lpp/failsafe_limit", "lpp/failsafe_limit", "power", "W", 0, 100000, 1),
+	number("lpp_failsafe_duration", "LPP failsafe duration", "lpp/failsafe_duration", "lpp/failsafe_duration", "duration", "s", 7200, 86400, 1),
+
+	// LPC
+	sensor("lpc_allowed_consumption", "LPC allowed consumption", "lpc/allowed_consumption", "power", "W"),
+	sensor("lpc_limit", "LPC limit", "lpc/limit", "power", "W"),
+	textSensor("lpc_state", "LPC state", "lpc/state"),
+	diagnosticSensor("lpc_last_heartbeat", "LPC last heartbeat", "lpc/last_heartbeat", "duration", "s"),
+	diagnosticSensor("lpc_limit_countdown", "LPC limit countdown", "lpc/LimitCountdown", "duration", "s"),
+	diagnosticSensor("lpc_failsafe_countdown", "LPC failsafe countdown", "lpc/FailsafeCountdown", "duration", "s"),
+	binarySensor("lpc_limit_active", "LPC limit active", "lpc/limit_activ"),
+	binarySensor("lpc_active", "LPC limit received", "lpc/active"),
+	number("lpc_nominal_max", "LPC nominal max", "lpc/NominalMax", "lpc/nominal_max", "power", "W", 0, 100000, 1),
+	number("lpc_failsafe_limit", "LPC failsafe limit", "lpc/failsafe_limit", "lpc/failsafe_limit", "power", "W", 0, 100000, 1),
+	number("lpc_failsafe_duration", "LPC failsafe duration", "lpc/failsafe_duration", "lpc/failsafe_duration", "duration", "s", 7200, 86400, 1),
+
+	// Pairing
+	diagnosticText("pairing_local_ski", "Local SKI", "pairing/local_ski"),
+	diagnosticText("pairing_qrcode", "Pairing QR code", "pairing/qrcode"),
+	visibleServicesSensor(),
+	text("pairing_pair", "Pair SKI", "pairing/pair"),
+	text("pairing_unpair", "Unpair SKI", "pairing/unpair"),
+	text("pairing_cancel", "Cancel pairing SKI", "pairing/cancel"),
+}
+
+func diagnosticText(objectId, name, topic string) discoveryEntity {
+	e := textSensor(objectId, name, topic)
+	e.category = "diagnostic"
+	return e
+}
+
+// number of visible services, the services are available as attributes
+func visibleServicesSensor() discoveryEntity {
+	e := textSensor("pairing_visible", "Visible EEBUS services", "pairing/visible_count")
+	e.attributes = "pairing/visible"
+	return e
+}
+
+// return the Home Assistant device of this bridge
+func discoveryDeviceInfo() discoveryDevice {
+	sn := hemsConfig().SN
+	return discoveryDevice{
+		Identifiers:  []string{"eebus2mqtt_" + sn},
+		Name:         "eebus2mqtt HEMS",
+		Manufacturer: "eebus2mqtt",
+		Model:        "HEMS",
+		SerialNumber: sn,
+	}
+}
+
+// return the discovery topic and payload of an entity
+func (e discoveryEntity) discoveryMessage(device discoveryDevice) (string, discoveryConfig) {
+	nodeId := "eebus2mqtt_" + hemsConfig().SN
+
+	cfg := discoveryConfig{
+		Name:              e.name,
+		UniqueId:          nodeId + "_" + e.objectId,
+		AvailabilityTopic: availabilityTopic,
+		DeviceClass:       e.deviceClass,
+		StateClass:        e.stateClass,
+		Unit:              e.unit,
+		EntityCategory:    e.category,
+		Device:            device,
+	}
+
+	if e.topic != "" {
+		cfg.StateTopic = topicPrefix + e.topic
+	}
+	if e.attributes != "" {
+		cfg.AttributesTopic = topicPrefix + e.attributes
+	}
+
+	switch e.component {
+	case "binary_sensor":
+		cfg.PayloadOn = "true"
+		cfg.PayloadOff = "false"
+	case "number":
+		cfg.CommandTopic = topicPrefix + e.command + commandTopicSuffix
+		cfg.Min = &e.min
+		cfg.Max = &e.max
+		cfg.Step = &e.step
+		cfg.Mode = "box"
+	case "text":
+		cfg.CommandTopic = topicPrefix + e.command + commandTopicSuffix
+	}
+
+	topic := fmt.Sprintf("%s/%s/%s/%s/config", discoveryPrefix, e.component, nodeId, e.objectId)
+	return topic, cfg
+}
+
+// announce all entities and mark the bridge as online
+func publishDiscovery(client mqtt.Client) {
+	publishDiscoveryEntities(client, discoveryEntities)
+	publishDiscoveryEntities(client, remoteDiscoveryEntities())
+	publishDiscoveryEntities(client, knownMGCPDiscoveryEntities())
+
+	client.Publish(availabilityTopic, qos, true, payloadOnline)
+}
+
+// announce the given entities
+func publishDiscoveryEntities(client mqtt.Client, entities []discoveryEntity) {
+	device := discoveryDeviceInfo()
+
+	for _, entity := range entities {
+		topic, cfg := entity.discoveryMessage(device)
+		payload, err := json.Marshal(cfg)
+		if err != nil {
+			continue
+		}
+		client.Publish(topic, qos, true, payload)
+	}
+}
+
+// remove the given entities from Home Assistant
+func clearDiscoveryEntities(client mqtt.Client, entities []discoveryEntity) {
+	device := discoveryDeviceInfo()
+
+	for _, entity := range entities {
+		topic, _ := entity.discoveryMessage(device)
+		client.Publish(topic, qos, true, "")
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/enbility/eebus-go/usecases/cs/limitstate"
+)
+
+// return if a limit applies in the given state
+func limitActive(state limitstate.State) bool {
+	switch state {
+	case limitstate.StateInit, limitstate.StateLimited, limitstate.StateFailsafe:
+		return true
+	default:
+		return false
+	}
+}
+
+// publish the state of a use case below eebus2mqtt/hems/<usecase>/
+func publishLimitState(usecase string, state limitstate.State) {
+	client.Publish(topicPrefix+usecase+"/state", qos, true, state.String())
+	client.Publish(topicPrefix+usecase+"/limit_activ", qos, true, fmt.Sprintf("%t", limitActive(state)))
+}
+
+// evaluate the state machine every second and publish the values in effect
+//
+// allowedTopic is the topic name for the power allowed in the current state
+func superviseLimits(ctx context.Context, sm *limitstate.StateMachine, usecase, allowedTopic string) {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	publishLimitState(usecase, sm.State())
+
+	for {
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			sm.Tick()
+
+			client.Publish(topicPrefix+usecase+"/last_heartbeat", qos, false, fmt.Sprintf("%.f", sm.HeartbeatAge().Seconds()))
+			if allowed, err := sm.ActivePowerLimit(); err == nil {
+				client.Publish(topicPrefix+usecase+"/"+allowedTopic, qos, true, fmt.Sprintf("%.f", allowed))
+			}
+			client.Publish(topicPrefix+usecase+"/LimitCountdown", qos, false, fmt.Sprintf("%.f", sm.LimitRemaining().Seconds()))
+			client.Publish(topicPrefix+usecase+"/FailsafeCountdown", qos, false, fmt.Sprintf("%.f", sm.FailsafeRemaining().Seconds()))
+		}
+	}
+}
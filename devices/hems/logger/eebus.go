@@ -0,0 +1,136 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/enbility/ship-go/logging"
+)
+
+// packages of the EEBUS stack by subsystem, the first matching prefix is used
+var packageSubsystems = []struct {
+	prefix    string
+	subsystem Subsystem
+}{
+	{"github.com/enbility/ship-go/", SHIP},
+	{"github.com/enbility/eebus-go/service", SHIP},
+	{"github.com/enbility/spine-go/", SPINE},
+	{"github.com/enbility/eebus-go/usecases/", UseCases},
+	{"github.com/enbility/eebus-go/features/", UseCases},
+	{"github.com/enbility/eebus-go/api", UseCases},
+}
+
+// return the subsystem of a function name
+func subsystemOf(function string) Subsystem {
+	for _, item := range packageSubsystems {
+		if strings.HasPrefix(function, item.prefix) {
+			return item.subsystem
+		}
+	}
+	return Bridge
+}
+
+// EEBUS returns the logging interface for the EEBUS stack
+//
+// The records are written to the logger of the subsystem of the calling package.
+func (l *Logger) EEBUS() logging.LoggingInterface {
+	return &eebusLogger{logger: l}
+}
+
+type eebusLogger struct {
+	logger *Logger
+}
+
+var _ logging.LoggingInterface = (*eebusLogger)(nil)
+
+// log a record with the subsystem and source of the caller of the interface method
+func (e *eebusLogger) log(level slog.Level, message func() string) {
+	// skip the caller lookup if no subsystem logs the level
+	enabled := false
+	for _, s := range Subsystems {
+		if level >= e.logger.Level(s) {
+			enabled = true
+			break
+		}
+	}
+	if !enabled {
+		return
+	}
+
+	// the caller of the interface method calling log
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+
+	logger := e.logger.For(subsystemOf(frame.Function))
+	ctx := context.Background()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	record := slog.NewRecord(time.Now(), level, message(), pcs[0])
+	_ = logger.Handler().Handle(ctx, record)
+}
+
+// join the arguments with spaces like fmt.Println
+func sprint(args []interface{}) func() string {
+	return func() string {
+		return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+	}
+}
+
+func sprintf(format string, args []interface{}) func() string {
+	return func() string {
+		return fmt.Sprintf(format, args...)
+	}
+}
+
+func (e *eebusLogger) Trace(args ...interface{}) {
+	e.log(LevelTrace, sprint(args))
+}
+
+func (e *eebusLogger) Tracef(format string, args ...interface{}) {
+	e.log(LevelTrace, sprintf(format, args))
+}
+
+func (e *eebusLogger) Debug(args ...interface{}) {
+	e.log(slog.LevelDebug, sprint(args))
+}
+
+func (e *eebusLogger) Debugf(format string, args ...interface{}) {
+	e.log(slog.LevelDebug, sprintf(format, args))
+}
+
+func (e *eebusLogger) Info(args ...interface{}) {
+	e.log(slog.LevelInfo, sprint(args))
+}
+
+func (e *eebusLogger) Infof(format string, args ...interface{}) {
+	e.log(slog.LevelInfo, sprintf(format, args))
+}
+
+func (e *eebusLogger) Error(args ...interface{}) {
+	e.log(slog.LevelError, sprint(args))
+}
+
+func (e *eebusLogger) Errorf(format string, args ...interface{}) {
+	e.log(slog.LevelError, sprintf(format, args))
+}
+
+// Printer writes the lines of a print style logger, e.g. of the MQTT client, at a level
+type Printer struct {
+	Logger *slog.Logger
+	Level  slog.Level
+}
+
+func (p Printer) Println(v ...interface{}) {
+	p.Logger.Log(context.Background(), p.Level, strings.TrimSpace(fmt.Sprintln(v...)))
+}
+
+func (p Printer) Printf(format string, v ...interface{}) {
+	p.Logger.Log(context.Background(), p.Level, strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"log/slog"
+)
+
+func (s *LoggerSuite) Test_SubsystemOf() {
+	s.Equal(SHIP, subsystemOf("github.com/enbility/ship-go/hub.(*Hub).connect"))
+	s.Equal(SHIP, subsystemOf("github.com/enbility/eebus-go/service.(*Service).Setup"))
+	s.Equal(SPINE, subsystemOf("github.com/enbility/spine-go/spine.(*DeviceLocal).ProcessCmd"))
+	s.Equal(UseCases, subsystemOf("github.com/enbility/eebus-go/usecases/cs/lpc.(*LPC).loadControlWriteCB"))
+	s.Equal(UseCases, subsystemOf("github.com/enbility/eebus-go/features/client.(*LoadControl).WriteLimitData"))
+	s.Equal(Bridge, subsystemOf("github.com/enbility/eebus-go/devices/hems/logger.(*LoggerSuite).Test_EEBUS"))
+	s.Equal(Bridge, subsystemOf("main.main"))
+}
+
+func (s *LoggerSuite) Test_EEBUS() {
+	l := s.newLogger(FormatText)
+	e := l.EEBUS()
+
+	// called from this package, routed to the bridge logger
+	e.Debug("hidden")
+	e.Info("a", 1, "b")
+	e.Errorf("failed: %d", 42)
+	s.NotContains(s.output.String(), "hidden")
+	s.Contains(s.output.String(), `msg="a 1 b"`)
+	s.Contains(s.output.String(), `msg="failed: 42"`)
+	s.Contains(s.output.String(), "subsystem=bridge")
+
+	s.output.Reset()
+	l.SetLevel(Bridge, LevelTrace)
+	e.Tracef("trace %s", "on")
+	s.Contains(s.output.String(), "level=TRACE")
+
+	s.output.Reset()
+	l.SetLevel(Bridge, slog.LevelError)
+	e.Info("hidden")
+	s.Empty(s.output.String())
+}
+
+func (s *LoggerSuite) Test_Printer() {
+	l := s.newLogger(FormatText)
+
+	p := Printer{Logger: l.For(MQTT), Level: slog.LevelWarn}
+	p.Println("[client]", "connection lost")
+	p.Printf("[net] %s\n", "retry")
+	s.Contains(s.output.String(), `msg="[client] connection lost"`)
+	s.Contains(s.output.String(), `msg="[net] retry"`)
+	s.Contains(s.output.String(), "level=WARN")
+}
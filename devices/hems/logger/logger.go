@@ -0,0 +1,281 @@
+// Package logger provides the leveled, structured logging of the HEMS bridge
+//
+// Every subsystem has its own logger with a level that can be changed at
+// runtime. The logs of the EEBUS stack are routed to the SHIP, SPINE and use
+// case loggers by the package of the caller. State transitions are written to
+// a separate audit log.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Subsystem names a part of the bridge with its own log level
+type Subsystem string
+
+const (
+	Bridge   Subsystem = "bridge"
+	SHIP     Subsystem = "ship"
+	SPINE    Subsystem = "spine"
+	UseCases Subsystem = "usecases"
+	MQTT     Subsystem = "mqtt"
+)
+
+// Subsystems are all subsystems
+var Subsystems = []Subsystem{Bridge, SHIP, SPINE, UseCases, MQTT}
+
+// LevelTrace is the level below debug used by the EEBUS stack
+const LevelTrace = slog.Level(-8)
+
+// output formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// names of the levels, from the least to the most verbose
+var levelNames = []struct {
+	name  string
+	level slog.Level
+}{
+	{"error", slog.LevelError},
+	{"warn", slog.LevelWarn},
+	{"info", slog.LevelInfo},
+	{"debug", slog.LevelDebug},
+	{"trace", LevelTrace},
+}
+
+// LevelNames returns the names of the supported levels
+func LevelNames() []string {
+	names := make([]string, 0, len(levelNames))
+	for _, item := range levelNames {
+		names = append(names, item.name)
+	}
+	return names
+}
+
+// ParseLevel returns the level of a name
+func ParseLevel(name string) (slog.Level, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, item := range levelNames {
+		if item.name == name {
+			return item.level, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level %q, use %s", name, strings.Join(LevelNames(), ", "))
+}
+
+// LevelName returns the name of a level
+func LevelName(level slog.Level) string {
+	for _, item := range levelNames {
+		if item.level == level {
+			return item.name
+		}
+	}
+	return strings.ToLower(level.String())
+}
+
+// ParseSubsystem returns the subsystem of a name
+func ParseSubsystem(name string) (Subsystem, error) {
+	s := Subsystem(strings.ToLower(strings.TrimSpace(name)))
+	if !slices.Contains(Subsystems, s) {
+		names := make([]string, 0, len(Subsystems))
+		for _, item := range Subsystems {
+			names = append(names, string(item))
+		}
+		return "", fmt.Errorf("unknown subsystem %q, use %s", name, strings.Join(names, ", "))
+	}
+	return s, nil
+}
+
+// Options configures a Logger
+type Options struct {
+	Format string                   // FormatText or FormatJSON, default text
+	Level  slog.Level               // level of all subsystems without their own level
+	Levels map[Subsystem]slog.Level // levels of single subsystems
+	Output io.Writer                // default os.Stderr
+	Audit  io.Writer                // audit log, default discarded
+}
+
+// Logger contains the loggers of the subsystems and the audit log
+type Logger struct {
+	levels  map[Subsystem]*slog.LevelVar
+	loggers map[Subsystem]*slog.Logger
+	audit   *slog.Logger
+}
+
+// New returns a Logger with the options
+func New(o Options) *Logger {
+	if o.Output == nil {
+		o.Output = os.Stderr
+	}
+	if o.Audit == nil {
+		o.Audit = io.Discard
+	}
+
+	l := &Logger{
+		levels:  map[Subsystem]*slog.LevelVar{},
+		loggers: map[Subsystem]*slog.Logger{},
+	}
+
+	// the subsystem handlers filter, the shared handler accepts all levels
+	handler := newHandler(o.Format, o.Output, LevelTrace)
+	for _, s := range Subsystems {
+		level := new(slog.LevelVar)
+		level.Set(o.Level)
+		if value, ok := o.Levels[s]; ok {
+			level.Set(value)
+		}
+		l.levels[s] = level
+		l.loggers[s] = slog.New(&levelHandler{Handler: handler, level: level}).With("subsystem", string(s))
+	}
+	l.audit = slog.New(newHandler(o.Format, o.Audit, slog.LevelInfo))
+
+	return l
+}
+
+// return a text or JSON handler printing the trace level by name
+func newHandler(format string, w io.Writer, level slog.Leveler) slog.Handler {
+	options := &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.LevelKey && len(groups) == 0 {
+				if level, ok := a.Value.Any().(slog.Level); ok && level <= LevelTrace {
+					a.Value = slog.StringValue("TRACE")
+				}
+			}
+			return a
+		},
+	}
+
+	if format == FormatJSON {
+		return slog.NewJSONHandler(w, options)
+	}
+	return slog.NewTextHandler(w, options)
+}
+
+// For returns the logger of a subsystem, unknown subsystems use the bridge logger
+func (l *Logger) For(s Subsystem) *slog.Logger {
+	if logger, ok := l.loggers[s]; ok {
+		return logger
+	}
+	return l.loggers[Bridge]
+}
+
+// Audit returns the logger of the audit log
+func (l *Logger) Audit() *slog.Logger {
+	return l.audit
+}
+
+// SetLevel changes the level of a subsystem, of all subsystems if s is empty
+func (l *Logger) SetLevel(s Subsystem, level slog.Level) {
+	for name, value := range l.levels {
+		if s == "" || s == name {
+			value.Set(level)
+		}
+	}
+}
+
+// Level returns the level of a subsystem
+func (l *Logger) Level(s Subsystem) slog.Level {
+	if level, ok := l.levels[s]; ok {
+		return level.Level()
+	}
+	return l.levels[Bridge].Level()
+}
+
+// Levels returns the levels of all subsystems as text, e.g. "info,ship=debug"
+//
+// The most common level is given first, the other subsystems by name. The
+// text is accepted by SetLevels.
+func (l *Logger) Levels() string {
+	count := map[slog.Level]int{}
+	for _, s := range Subsystems {
+		count[l.Level(s)]++
+	}
+	common := l.Level(Bridge)
+	for level, n := range count {
+		if n > count[common] || (n == count[common] && level > common) {
+			common = level
+		}
+	}
+
+	parts := []string{LevelName(common)}
+	for _, s := range Subsystems {
+		if level := l.Level(s); level != common {
+			parts = append(parts, string(s)+"="+LevelName(level))
+		}
+	}
+	sort.Strings(parts[1:])
+	return strings.Join(parts, ",")
+}
+
+// SetLevels changes the levels given as text
+//
+// The text contains levels separated by commas, e.g. "info,ship=debug,mqtt=trace".
+// A level without subsystem applies to all subsystems and is set first. The
+// text is checked completely before any level is changed.
+func (l *Logger) SetLevels(text string) error {
+	type change struct {
+		subsystem Subsystem
+		level     slog.Level
+	}
+	var changes []change
+
+	for _, part := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' }) {
+		name, value, found := strings.Cut(part, "=")
+		if !found {
+			name, value = "", name
+		}
+
+		item := change{}
+		var err error
+		if found {
+			if item.subsystem, err = ParseSubsystem(name); err != nil {
+				return err
+			}
+		}
+		if item.level, err = ParseLevel(value); err != nil {
+			return err
+		}
+		changes = append(changes, item)
+	}
+	if len(changes) == 0 {
+		return fmt.Errorf("no log level given, use e.g. info or ship=debug")
+	}
+
+	// the level of all subsystems first, the single subsystems override it
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].subsystem == "" && changes[j].subsystem != ""
+	})
+	for _, item := range changes {
+		l.SetLevel(item.subsystem, item.level)
+	}
+
+	return nil
+}
+
+// levelHandler filters the records of a subsystem by its level
+type levelHandler struct {
+	slog.Handler
+	level *slog.LevelVar
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+)
+
+func (s *LoggerSuite) Test_ParseLevel() {
+	level, err := ParseLevel("trace")
+	s.Nil(err)
+	s.Equal(LevelTrace, level)
+	s.Equal("trace", LevelName(level))
+
+	level, err = ParseLevel(" WARN ")
+	s.Nil(err)
+	s.Equal(slog.LevelWarn, level)
+
+	_, err = ParseLevel("verbose")
+	s.ErrorContains(err, "unknown log level")
+}
+
+func (s *LoggerSuite) Test_Levels() {
+	l := s.newLogger(FormatText)
+
+	l.For(MQTT).Debug("hidden")
+	l.For(MQTT).Info("shown")
+	s.NotContains(s.output.String(), "hidden")
+	s.Contains(s.output.String(), "subsystem=mqtt")
+	s.Contains(s.output.String(), "msg=shown")
+
+	s.Nil(l.SetLevels("ship=trace,warn"))
+	s.Equal(slog.LevelWarn, l.Level(Bridge))
+	s.Equal(LevelTrace, l.Level(SHIP))
+	s.Equal("warn,ship=trace", l.Levels())
+
+	s.output.Reset()
+	l.For(SHIP).Log(context.Background(), LevelTrace, "trace")
+	s.Contains(s.output.String(), "level=TRACE")
+
+	s.ErrorContains(l.SetLevels("modbus=debug"), "unknown subsystem")
+	s.ErrorContains(l.SetLevels("info,ship=loud"), "unknown log level")
+	s.Equal("warn,ship=trace", l.Levels(), "invalid levels change nothing")
+	s.NotNil(l.SetLevels(""))
+}
+
+func (s *LoggerSuite) Test_JSON() {
+	l := s.newLogger(FormatJSON)
+
+	l.For(Bridge).Info("started", "port", 4713)
+	l.Audit().Info("limited", "state", "limited")
+
+	var record map[string]any
+	s.Require().NoError(json.Unmarshal(s.output.Bytes(), &record))
+	s.Equal("started", record["msg"])
+	s.Equal("bridge", record["subsystem"])
+	s.Equal(float64(4713), record["port"])
+
+	s.Require().NoError(json.Unmarshal(s.audit.Bytes(), &record))
+	s.Equal("limited", record["state"])
+	s.NotContains(s.output.String(), "limited")
+}
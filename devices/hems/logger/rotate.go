@@ -0,0 +1,226 @@
+package logger
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// time format in the names of rotated files
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// the time of a text or JSON record
+var recordTimePattern = regexp.MustCompile(`(?:^|\s)time=(\S+)|"time":"([^"]+)"`)
+
+// RotateOptions configures a RotatingFile
+type RotateOptions struct {
+	MaxSize    int64            // bytes, the file is rotated before it gets larger, 0 = no limit
+	MaxAge     time.Duration    // the file is rotated and rotated files are deleted once older, 0 = no limit
+	MaxBackups int              // number of rotated files to keep, 0 = all
+	Now        func() time.Time // time source, time.Now if nil
+}
+
+// RotatingFile is a log file that is rotated by size and age
+//
+// A rotated file is renamed to <name>-<time><ext>, e.g. status-2026-01-02T15-04-05.000.log.
+// The age of the file counts from the last rotation. A file that was never rotated
+// counts from its first record, or its modification time if the record has no time.
+// Rotated files are deleted by age and count.
+type RotatingFile struct {
+	path    string
+	options RotateOptions
+
+	mux     sync.Mutex
+	file    *os.File
+	size    int64
+	started time.Time // start of the current file
+}
+
+// OpenRotating opens or creates the file at path for appending
+func OpenRotating(path string, options RotateOptions) (*RotatingFile, error) {
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+	f := &RotatingFile{path: path, options: options}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	// an existing file was started at the last rotation or with its first record
+	if f.size > 0 {
+		if backups := f.backups(); len(backups) > 0 {
+			f.started = backups[0].time
+		} else {
+			f.started = f.firstRecordTime()
+		}
+	}
+	f.prune()
+
+	return f, nil
+}
+
+// open the file and read its size
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.started = f.options.Now()
+	return nil
+}
+
+// return the time of the first record, or the modification time if it has none
+func (f *RotatingFile) firstRecordTime() time.Time {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return f.options.Now()
+	}
+	defer file.Close()
+
+	line, _ := bufio.NewReader(io.LimitReader(file, 4096)).ReadString('\n')
+	if match := recordTimePattern.FindStringSubmatch(line); match != nil {
+		if t, err := time.Parse(time.RFC3339, match[1]+match[2]); err == nil {
+			return t
+		}
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return f.options.Now()
+	}
+	return info.ModTime()
+}
+
+// Write appends p, the file is rotated first if p does not fit or the file is too old
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	tooLarge := f.options.MaxSize > 0 && f.size+int64(len(p)) > f.options.MaxSize
+	tooOld := f.options.MaxAge > 0 && f.options.Now().Sub(f.started) >= f.options.MaxAge
+	if f.size > 0 && (tooLarge || tooOld) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate renames the current file and starts a new one
+func (f *RotatingFile) Rotate() error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if f.file == nil {
+		return os.ErrClosed
+	}
+	return f.rotate()
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	renameErr := os.Rename(f.path, f.backupName(f.options.Now()))
+	if err := f.open(); err != nil {
+		return errors.Join(renameErr, err)
+	}
+	if renameErr != nil {
+		return fmt.Errorf("unable to rotate %s: %w", f.path, renameErr)
+	}
+
+	f.prune()
+	return nil
+}
+
+// Close closes the file
+func (f *RotatingFile) Close() error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// return the name of a file rotated at t
+func (f *RotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-" + t.Format(backupTimeFormat) + ext
+}
+
+// a rotated file with the time of rotation
+type backup struct {
+	path string
+	time time.Time
+}
+
+// return the rotated files, the newest first
+func (f *RotatingFile) backups() []backup {
+	ext := filepath.Ext(f.path)
+	prefix := filepath.Base(strings.TrimSuffix(f.path, ext)) + "-"
+
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return nil
+	}
+
+	var backups []backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		t, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext), time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(filepath.Dir(f.path), name), time: t})
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].time.After(backups[j].time) })
+	return backups
+}
+
+// delete the rotated files exceeding the age or count
+func (f *RotatingFile) prune() {
+	now := f.options.Now()
+	for i, item := range f.backups() {
+		tooMany := f.options.MaxBackups > 0 && i >= f.options.MaxBackups
+		tooOld := f.options.MaxAge > 0 && now.Sub(item.time) > f.options.MaxAge
+		if tooMany || tooOld {
+			_ = os.Remove(item.path)
+		}
+	}
+}
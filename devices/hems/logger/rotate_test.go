@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func (s *LoggerSuite) Test_RotatingFile_Size() {
+	path := filepath.Join(s.dir, "logs", "status.log")
+	f, err := OpenRotating(path, RotateOptions{MaxSize: 10, MaxBackups: 2})
+	s.Require().NoError(err)
+	defer f.Close()
+
+	line := []byte("12345678\n")
+	for i := 0; i < 5; i++ {
+		_, err = f.Write(line)
+		s.Nil(err)
+		// the backups are named by the time of rotation
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	data, err := os.ReadFile(path)
+	s.Nil(err)
+	s.Equal(line, data)
+	s.Len(f.backups(), 2)
+
+	// a line larger than the limit is written to an empty file
+	s.Nil(f.Rotate())
+	_, err = f.Write([]byte(strings.Repeat("x", 20)))
+	s.Nil(err)
+	info, err := os.Stat(path)
+	s.Nil(err)
+	s.Equal(int64(20), info.Size())
+}
+
+func (s *LoggerSuite) Test_RotatingFile_Age() {
+	path := filepath.Join(s.dir, "status.log")
+	f, err := OpenRotating(path, RotateOptions{})
+	s.Require().NoError(err)
+
+	old := f.backupName(time.Now().Add(-48 * time.Hour))
+	recent := f.backupName(time.Now().Add(-time.Hour))
+	s.Require().NoError(os.WriteFile(old, nil, 0644))
+	s.Require().NoError(os.WriteFile(recent, nil, 0644))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, "status-other.log"), nil, 0644))
+	s.Nil(f.Close())
+
+	f, err = OpenRotating(path, RotateOptions{MaxAge: 24 * time.Hour})
+	s.Require().NoError(err)
+	defer f.Close()
+
+	s.NoFileExists(old)
+	s.FileExists(recent)
+	s.FileExists(filepath.Join(s.dir, "status-other.log"))
+
+	s.Nil(f.Close())
+	_, err = f.Write([]byte("closed"))
+	s.ErrorIs(err, os.ErrClosed)
+}
+
+func (s *LoggerSuite) Test_RotatingFile_RotateByAge() {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)
+	clock := func() time.Time { return now }
+
+	path := filepath.Join(s.dir, "status.log")
+	f, err := OpenRotating(path, RotateOptions{MaxAge: 24 * time.Hour, Now: clock})
+	s.Require().NoError(err)
+
+	line := []byte("line\n")
+	_, err = f.Write(line)
+	s.Nil(err)
+
+	now = now.Add(23 * time.Hour)
+	_, err = f.Write(line)
+	s.Nil(err)
+	s.Empty(f.backups())
+
+	// a quiet file is rotated by age on the next write
+	now = now.Add(time.Hour)
+	_, err = f.Write(line)
+	s.Nil(err)
+	backups := f.backups()
+	s.Require().Len(backups, 1)
+	s.Equal(now, backups[0].time)
+
+	data, err := os.ReadFile(path)
+	s.Nil(err)
+	s.Equal(line, data)
+	data, err = os.ReadFile(backups[0].path)
+	s.Nil(err)
+	s.Equal(append(line, line...), data)
+
+	// a reopened file keeps its age since the last rotation
+	s.Nil(f.Close())
+	now = now.Add(23 * time.Hour)
+	f, err = OpenRotating(path, RotateOptions{MaxAge: 24 * time.Hour, Now: clock})
+	s.Require().NoError(err)
+	defer f.Close()
+
+	now = now.Add(time.Hour)
+	_, err = f.Write(line)
+	s.Nil(err)
+	s.Len(f.backups(), 2)
+}
+
+func (s *LoggerSuite) Test_RotatingFile_ReopenOldFile() {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)
+	clock := func() time.Time { return now }
+	started := now.Add(-25 * time.Hour)
+
+	tests := []struct {
+		name  string
+		first string
+	}{
+		{name: "text", first: "time=" + started.Format(time.RFC3339Nano) + " level=INFO msg=\"bridge started\"\n"},
+		{name: "JSON", first: `{"time":"` + started.Format(time.RFC3339Nano) + `","level":"INFO","msg":"bridge started"}` + "\n"},
+		{name: "no time", first: "bridge started\n"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			dir := s.T().TempDir()
+			path := filepath.Join(dir, "status.log")
+			s.Require().NoError(os.WriteFile(path, []byte(test.first+"line\n"), 0644))
+			s.Require().NoError(os.Chtimes(path, started, started))
+
+			// a file without backups keeps its age when it is opened again
+			f, err := OpenRotating(path, RotateOptions{MaxAge: 24 * time.Hour, Now: clock})
+			s.Require().NoError(err)
+			defer f.Close()
+			s.True(started.Equal(f.started))
+
+			_, err = f.Write([]byte("line\n"))
+			s.Nil(err)
+			s.Len(f.backups(), 1)
+		})
+	}
+}
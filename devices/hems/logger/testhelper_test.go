@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLoggerSuite(t *testing.T) {
+	suite.Run(t, new(LoggerSuite))
+}
+
+type LoggerSuite struct {
+	suite.Suite
+
+	dir    string
+	output *bytes.Buffer
+	audit  *bytes.Buffer
+}
+
+func (s *LoggerSuite) BeforeTest(suiteName, testName string) {
+	s.dir = s.T().TempDir()
+	s.output = &bytes.Buffer{}
+	s.audit = &bytes.Buffer{}
+}
+
+// return a logger writing to the test buffers
+func (s *LoggerSuite) newLogger(format string) *Logger {
+	return New(Options{Format: format, Output: s.output, Audit: s.audit})
+}
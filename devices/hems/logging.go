@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+	"github.com/enbility/eebus-go/devices/hems/logger"
+	"github.com/enbility/eebus-go/usecases/cs/limitstate"
+)
+
+// Logging
+//
+// The bridge, the EEBUS stack and the MQTT client log to stderr, each
+// subsystem with its own level. The levels can be changed at runtime on
+// eebus2mqtt/hems/log/level/set, e.g. with "debug" or "info,ship=trace".
+// The current levels are published retained on eebus2mqtt/hems/log/level.
+// State transitions are written to the audit log in the data directory.
+const logLevelCommand = "log/level"
+
+// the loggers, replaced by setupLogging once the config is loaded
+var logs = logger.New(logger.Options{})
+
+// the audit log file of the running bridge
+var auditFile *logger.RotatingFile
+
+func bridgeLog() *slog.Logger {
+	return logs.For(logger.Bridge)
+}
+
+func mqttLog() *slog.Logger {
+	return logs.For(logger.MQTT)
+}
+
+func usecaseLog() *slog.Logger {
+	return logs.For(logger.UseCases)
+}
+
+// set up the loggers of the config, the audit log is only opened for the bridge
+func setupLogging(withAudit bool) error {
+	cfg := config.Log
+
+	options := logger.Options{
+		Format: cfg.Format,
+		Levels: map[logger.Subsystem]slog.Level{},
+	}
+	if options.Format == "" {
+		options.Format = hemsconfig.DefaultLogFormat
+	}
+
+	level := cfg.Level
+	if level == "" {
+		level = hemsconfig.DefaultLogLevel
+	}
+	var err error
+	if options.Level, err = logger.ParseLevel(level); err != nil {
+		return err
+	}
+	for name, value := range cfg.Levels {
+		s, err := logger.ParseSubsystem(name)
+		if err != nil {
+			return err
+		}
+		if options.Levels[s], err = logger.ParseLevel(value); err != nil {
+			return err
+		}
+	}
+
+	if withAudit {
+		if auditFile != nil {
+			auditFile.Close()
+		}
+		if auditFile, err = openAuditFile(cfg); err != nil {
+			return fmt.Errorf("unable to open the audit log: %w", err)
+		}
+		options.Audit = auditFile
+	}
+
+	logs = logger.New(options)
+
+	// the MQTT client logs its internals, the debug output is very verbose
+	mqtt.ERROR = logger.Printer{Logger: mqttLog(), Level: slog.LevelError}
+	mqtt.CRITICAL = logger.Printer{Logger: mqttLog(), Level: slog.LevelError}
+	mqtt.WARN = logger.Printer{Logger: mqttLog(), Level: slog.LevelWarn}
+	mqtt.DEBUG = logger.Printer{Logger: mqttLog(), Level: logger.LevelTrace}
+
+	return nil
+}
+
+// open the audit log, relative paths are in the data directory
+func openAuditFile(cfg hemsconfig.Log) (*logger.RotatingFile, error) {
+	path := cfg.AuditFile
+	if path == "" {
+		path = hemsconfig.DefaultAuditFile
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(store.DataDir(), path)
+	}
+
+	options := logger.RotateOptions{
+		MaxSize:    int64(hemsconfig.DefaultAuditMaxSize) << 20,
+		MaxAge:     time.Duration(hemsconfig.DefaultAuditMaxAge) * 24 * time.Hour,
+		MaxBackups: hemsconfig.DefaultAuditMaxBackups,
+	}
+	if cfg.AuditMaxSize > 0 {
+		options.MaxSize = int64(cfg.AuditMaxSize) << 20
+	}
+	if cfg.AuditMaxAge > 0 {
+		options.MaxAge = time.Duration(cfg.AuditMaxAge) * 24 * time.Hour
+	}
+	if cfg.AuditMaxBackups > 0 {
+		options.MaxBackups = cfg.AuditMaxBackups
+	}
+
+	return logger.OpenRotating(path, options)
+}
+
+// write a state transition to the audit log and the bridge log
+func auditState(state limitstate.State, msg string, args ...any) {
+	args = append([]any{"state", state.String()}, args...)
+	logs.Audit().Info(msg, args...)
+	bridgeLog().Info(msg, args...)
+}
+
+// write an event to the audit log and the bridge log
+func auditEvent(msg string, args ...any) {
+	logs.Audit().Info(msg, args...)
+	bridgeLog().Info(msg, args...)
+}
+
+// log an error and exit
+func fatal(msg string, args ...any) {
+	bridgeLog().Error(msg, args...)
+	if auditFile != nil {
+		auditFile.Close()
+	}
+	os.Exit(1)
+}
+
+// publish the current log levels
+func publishLogLevels(client mqtt.Client) {
+	client.Publish(topicPrefix+logLevelCommand, qos, true, logs.Levels())
+}
+
+// change the log levels on eebus2mqtt/hems/log/level/set
+//
+// The levels are not saved, set log.level in the config to keep them.
+func onLogLevelMessage(client mqtt.Client, payload []byte) {
+	ack := commandAck{Command: logLevelCommand}
+
+	text := strings.TrimSpace(string(payload))
+	if err := logs.SetLevels(text); err != nil {
+		ack.Error = err.Error()
+		bridgeLog().Warn("MQTT command rejected", "command", logLevelCommand, "error", err)
+	} else {
+		ack.Success = true
+		bridgeLog().Info("log levels changed", "levels", logs.Levels())
+		publishLogLevels(client)
+	}
+
+	data, _ := json.Marshal(ack)
+	client.Publish(topicPrefix+logLevelCommand+ackTopicSuffix, qos, false, data)
+}
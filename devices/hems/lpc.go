@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/usecases/cs/limitstate"
+	cslpc "github.com/enbility/eebus-go/usecases/cs/lpc"
+	spineapi "github.com/enbility/spine-go/api"
+)
+
+// default values for the consumption limitation (§14a EnWG)
+const (
+	defaultLPCNominalMax       = 32000
+	defaultLPCFailsafe         = 4200
+	defaultLPCFailsafeDuration = 7200
+)
+
+var cancelLPC context.CancelFunc
+
+// return the nominal max consumption from the config
+func lpcNominalMax() int {
+	if value := hemsConfig().LPCMax; value > 0 {
+		return value
+	}
+	return defaultLPCNominalMax
+}
+
+// publish the current LPC values
+func (h *hems) publishLPC() {
+	if nominalMax, err := h.uccslpc.ConsumptionNominalMax(); err == nil {
+		client.Publish("eebus2mqtt/hems/lpc/NominalMax", qos, true, fmt.Sprintf("%.f", nominalMax))
+	}
+	if currentLimit, err := h.uccslpc.ConsumptionLimit(); err == nil {
+		client.Publish("eebus2mqtt/hems/lpc/limit", qos, true, fmt.Sprintf("%.f", currentLimit.Value))
+		client.Publish("eebus2mqtt/hems/lpc/active", qos, true, fmt.Sprintf("%t", currentLimit.IsActive))
+	}
+	if currentLimit, isChangeable, err := h.uccslpc.FailsafeConsumptionActivePowerLimit(); err == nil {
+		client.Publish("eebus2mqtt/hems/lpc/failsafe_limit", qos, true, fmt.Sprintf("%.f", currentLimit))
+		client.Publish("eebus2mqtt/hems/lpc/failsafe_changeable", qos, true, fmt.Sprintf("%t", isChangeable))
+	}
+	if duration, _, err := h.uccslpc.FailsafeDurationMinimum(); err == nil {
+		client.Publish("eebus2mqtt/hems/lpc/failsafe_duration", qos, true, fmt.Sprintf("%.f", duration.Seconds()))
+	}
+}
+
+// Controllable System LPC Event Handler
+
+func (h *hems) OnLPCEvent(ski string, device spineapi.DeviceRemoteInterface, entity spineapi.EntityRemoteInterface, event api.EventType) {
+	switch event {
+	case cslpc.WriteApprovalRequired:
+		h.lpcApprover.handle(ski, h.uccslpc.PendingConsumptionLimits())
+
+	case cslpc.DataUpdateLimit:
+		if currentLimit, err := h.uccslpc.ConsumptionLimit(); err == nil {
+			usecaseLog().Info("new LPC limit", "limit", currentLimit.Value, "active", currentLimit.IsActive)
+			client.Publish("eebus2mqtt/hems/lpc/limit", qos, true, fmt.Sprintf("%.f", currentLimit.Value))
+			client.Publish("eebus2mqtt/hems/lpc/active", qos, true, fmt.Sprintf("%t", currentLimit.IsActive))
+			h.lpcState.LimitUpdated()
+		}
+
+	case cslpc.DataUpdateHeartbeat:
+		h.lpcState.Heartbeat()
+
+	case cslpc.DataUpdateFailsafeConsumptionActivePowerLimit:
+		if currentLimit, isChangeable, err := h.uccslpc.FailsafeConsumptionActivePowerLimit(); err == nil {
+			usecaseLog().Info("new LPC failsafe consumption active power limit", "limit", currentLimit)
+			updateConfig(func() { config.Hems.FailsafeValues.LPC.SetLimit(int(currentLimit)) })
+			client.Publish("eebus2mqtt/hems/lpc/failsafe_limit", qos, true, fmt.Sprintf("%.f", currentLimit))
+			client.Publish("eebus2mqtt/hems/lpc/failsafe_changeable", qos, true, fmt.Sprintf("%t", isChangeable))
+		}
+
+	case cslpc.DataUpdateFailsafeDurationMinimum:
+		if duration, _, err := h.uccslpc.FailsafeDurationMinimum(); err == nil {
+			usecaseLog().Info("new LPC failsafe duration minimum", "duration", duration)
+			updateConfig(func() { config.Hems.FailsafeValues.LPC.SetDuration(int(duration.Seconds())) })
+			client.Publish("eebus2mqtt/hems/lpc/failsafe_duration", qos, true, fmt.Sprintf("%.f", duration.Seconds()))
+		}
+	}
+}
+
+// LPC heartbeat and limit supervision, equivalent to EKG for LPP
+func EKGLPC(h *hems) {
+	if cancelLPC != nil {
+		cancelLPC()
+	}
+
+	var ctx context.Context
+	ctx, cancelLPC = context.WithCancel(context.Background())
+
+	h.publishLPC()
+
+	go superviseLimits(ctx, h.lpcState, "lpc", "allowed_consumption")
+}
+
+// log and publish LPC state changes
+func (h *hems) onLPCStateChange(from, to limitstate.State) {
+	value, _ := h.lpcState.ActivePowerLimit()
+	publishLimitState("lpc", to)
+	auditState(to, "LPC state changed", "from", from.String(), "limit", value)
+}
@@ -2,349 +2,111 @@ package main
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/rand"
 	"crypto/tls"
-	"crypto/x509"
-	"encoding/json" // CHANGE: added for reading config
-	"encoding/pem"
+	"flag"
 	"fmt"
-	"log"
 	"net"
 	"os"
 	"os/signal"
-	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
-	"crypto/aes"
-	"crypto/cipher"
-	"errors"
-	"regexp"
-
-	"encoding/base64"
-
-	"golang.org/x/crypto/argon2"
-
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/devices/hems/certs"
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
 	"github.com/enbility/eebus-go/service"
 	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/enbility/eebus-go/usecases/cem/evsecc"
+	"github.com/enbility/eebus-go/usecases/cs/limitstate"
 	cslpc "github.com/enbility/eebus-go/usecases/cs/lpc"
 	cslpp "github.com/enbility/eebus-go/usecases/cs/lpp"
-
-	// eglpc "github.com/enbility/eebus-go/usecases/eg/lpc"
-	// eglpp "github.com/enbility/eebus-go/usecases/eg/lpp"
 	"github.com/enbility/eebus-go/usecases/ma/mgcp"
 	shipapi "github.com/enbility/ship-go/api"
-	"github.com/enbility/ship-go/cert"
+	shiputil "github.com/enbility/ship-go/util"
 	spineapi "github.com/enbility/spine-go/api"
 	"github.com/enbility/spine-go/model"
 )
 
-// CHANGE: configuration struct for JSON config file
+var config hemsconfig.Config
+var store *hemsconfig.Store
 
-type Config struct {
-	Hems HemsConfig `json:"hems"`
-	Mqtt MqttConfig `json:"mqtt"`
-}
-
-type HemsConfig struct {
-	CertFile         string `json:"certFile"`
-	KeyFile          string `json:"keyFile"`
-	RemoteSKI        string `json:"remoteSki"`
-	Port             int    `json:"port"`
-	PVMax            int    `json:"pv_max"`
-	Failsafe         string `json:"failsafe"`
-	FailsafeDuration string `json:"failsafe_duration"`
-	SN               string `json:"serial_number"`
-}
-type MqttConfig struct {
-	Broker   string `json:"mqttBroker"`
-	Port     int    `json:"mqttPort"`
-	Username string `json:"mqttUsername"`
-	Password string `json:"mqttPassword"`
-}
-
-var config Config
-var remoteSki string
-var configfile string = "./config.json"
-var logfile string = "./status.log"
+// guards config, the MQTT and SPINE handlers change it concurrently
+var configMux sync.Mutex
 var client mqtt.Client
-var ekg time.Time = time.Now()
-var isFailsafe bool = false
 var cancel context.CancelFunc
+var started time.Time
 
-type SystemState int
-
+// default values for the production limitation
 const (
-	StateInit SystemState = iota
-	StateLimited
-	StateUnlimitedControlled
-	StateFailsafe
-	Info
+	defaultLPPFailsafe         = 4200
+	defaultLPPFailsafeDuration = 7200
 )
 
-// CHANGE: attempt to read a JSON config file at startup and inject cert/key (and optional remote ski/port)
-// If a config is present and command-line args do not contain cert/key, we patch os.Args so existing run() logic
-// (which expects cert/key at os.Args[3]/os.Args[4] when len(os.Args) == 5) works unchanged.
+// load the config and set up the loggers
 //
-// The config file path is "./config.json" by default. To change, set CONFIG_FILE env var.
-func init() {
-	var certificate tls.Certificate
-	// check for log
-	_, err := os.Open(logfile)
-
-	if err != nil {
-
-		//check for HA log
-		if _, err := os.Stat("/config"); !os.IsNotExist(err) {
-			logfile = "/config/status.log"
-			_, err = os.Open(logfile)
-			if err != nil {
-				// HA first run?
-				log.Println("Info_init: no log file found — I will generate it.")
-
-				_, _ = os.Create(logfile)
-			}
-		} else {
-			// not HA
-			log.Println("Info_init: no log file found — I will generate it.")
-
-			_, _ = os.Create(logfile)
-		}
-	}
-
-	WriteLog(logfile, StateInit)
-
-	// default config file path
-	cfgPath, err := os.Open(configfile)
-	if err != nil {
-		// check for HA config
-		if _, err := os.Stat("/config"); !os.IsNotExist(err) {
-			configfile = "/config/config.json"
-			cfgPath, err = os.Open(configfile)
-			if err != nil {
-				// HA first run?
-				log.Println("no HA config")
-
-				generateConfic(configfile)
-			}
-		} else {
-			// not HA
-			generateConfic(configfile)
-		}
-
-	}
-
-	// cfgPath, err = os.Open(configfile)
-	// if err != nil {
-	// 	log.Fatalf("Could not open config file: %s", err)
-	// }
-	println(cfgPath.Name())
-	defer cfgPath.Close()
-
-	decoder := json.NewDecoder(cfgPath)
-	err = decoder.Decode(&config)
-	if err != nil {
-		log.Fatalf("Unable to marshal JSON due to %s", err)
-		//log.Println("Warning: failed to parse config.json:", err)
-	}
-
-	cfg := config.Hems
-
-	if cfg.CertFile == "" || cfg.KeyFile == "" || cfg.SN == "" {
-
-		// No config file present — not an error, just skip injection
-		println("Info: no certificate file found at", configfile, "— I will generate it.")
-
-		const digits = "0123456789"
-		b := make([]byte, 10)
-		_, err := rand.Read(b)
-		if err != nil {
-			return
-		}
-		for i := 0; i < 10; i++ {
-			b[i] = digits[int(b[i])%10]
-		}
-		cfg.SN = string(b)
-
-		certificate, err = cert.CreateCertificate("eebus2mqtt", "eebus-go", "HEMS", cfg.SN)
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		pemdata := pem.EncodeToMemory(&pem.Block{
-			Type:  "CERTIFICATE",
-			Bytes: certificate.Certificate[0],
-		})
-		cfg.CertFile = string(pemdata)
-
-		b, err = x509.MarshalECPrivateKey(certificate.PrivateKey.(*ecdsa.PrivateKey))
-		if err != nil {
-			log.Fatal(err)
-		}
-		pemdata = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b})
-		cfg.KeyFile = string(pemdata)
-
-		// cfg.RemoteSKI = "replace-with-remote-ski"
-		// choose a starting port and find the next free one
-		cfg.Port = availablePort(4713)
-		config.Hems = cfg
-		cfgPath, _ = os.Create(configfile)
-		encoder := json.NewEncoder(cfgPath)
-		encoder.SetIndent("", "  ")
-		_ = encoder.Encode(config)
-		cfgPath.Close()
-		return
-
-	}
-
-	// if err := json.Unmarshal(data, &cfg); err != nil {
-	// 	// malformed config — log and skip
-	// 	fmt.Println("Warning: failed to parse config.json:", err)
-	// 	return
-	// }
-
-	fmt.Println("Info: injected cert/key (and optional port/remoteSki) from config file:", cfgPath)
-
-}
-
-func WriteLog(logfile string, state SystemState, remark ...string) error {
-	// Logdatei öffnen (muss existieren)
-	f, err := os.OpenFile(logfile, os.O_APPEND|os.O_WRONLY, 0644)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	// Zeitstempel
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-
-	// optionale Bemerkung
-	var note string
-	if len(remark) > 0 {
-		note = remark[0]
+// overrides are the values set by environment variables and command line flags
+func loadConfig(overrides ...hemsconfig.Overrides) {
+	var err error
+	config, store, err = hemsconfig.Load(overrides...)
+	if store == nil {
+		fatal("unable to load the config", "error", err)
 	}
 
-	// Logzeile
-	line := fmt.Sprintf("%s | %s | %s\n", timestamp, state.String(), note)
-
-	// Schreiben
-	_, err = f.WriteString(line)
-	return err
-}
-
-func (s SystemState) String() string {
-	switch s {
-	case StateInit:
-		return "init"
-	case StateLimited:
-		return "limited"
-	case StateUnlimitedControlled:
-		return "unlimitedControlled"
-	case StateFailsafe:
-		return "failsafe"
-	default:
-		return "unknown"
+	if store.Created() {
+		bridgeLog().Info("no config file found, generated it", "path", store.Path())
 	}
-}
-
-func generateConfic(conffile string) {
-	log.Println("Info: no config file found at", conffile, "— I will generate it.")
-	// Create basic config structure
-	const digits = "0123456789"
-	b := make([]byte, 10)
-	_, err := rand.Read(b)
+	// the validation errors are listed one per line
 	if err != nil {
-		return
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
-	for i := 0; i < 10; i++ {
-		b[i] = digits[int(b[i])%10]
-	}
-	sn := string(b)
-	config = Config{
-		Hems: HemsConfig{
-			CertFile:  "",
-			KeyFile:   "",
-			RemoteSKI: "",
-			Port:      0,
-			PVMax:     10000,
-			Failsafe:  "",
-			SN:        sn,
-		},
-		Mqtt: MqttConfig{
-			Broker:   "",
-			Username: "",
-			Port:     1883,
-			Password: "",
-		},
+	if err := setupLogging(false); err != nil {
+		fatal("unable to set up logging", "error", err)
 	}
-	file, _ := os.Create(conffile)
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	_ = encoder.Encode(config)
-	file.Close()
+	bridgeLog().Debug("config loaded", "path", store.Path())
 }
 
 type hems struct {
 	myService *service.Service
 
-	uccslpc ucapi.CsLPCInterface
-	uccslpp ucapi.CsLPPInterface
-	//uceglpc   ucapi.EgLPCInterface
-	//uceglpp   ucapi.EgLPPInterface
+	uccslpc  ucapi.CsLPCInterface
+	uccslpp  ucapi.CsLPPInterface
 	ucmamgcp ucapi.MaMGCPInterface
+	ucevsecc ucapi.CemEVSECCInterface
+
+	lppState *limitstate.StateMachine
+	lpcState *limitstate.StateMachine
+
+	lppApprover *limitApprover
+	lpcApprover *limitApprover
 }
 
 func (h *hems) run() {
 	var err error
-	var certificate tls.Certificate
 	var port int
 
-	cfg := config.Hems
-
-	if cfg.CertFile != "" && cfg.KeyFile != "" {
-
-		remoteSki = cfg.RemoteSKI
-		if remoteSki == "" || remoteSki == "replace-with-remote-ski" {
-			log.Fatal("Please add Remote SKI to config.")
-		}
+	cfg := hemsConfig()
 
-		cert := []byte(cfg.CertFile)
-		key := []byte(cfg.KeyFile)
-
-		certificate, err = tls.X509KeyPair(cert, key)
-		if err != nil {
-			println("Error loading certificate and key:", err)
-			log.Fatal(err)
-		}
-	} else {
-		println("No config")
-		log.Fatal(err)
+	if len(remoteConfigs()) == 0 {
+		bridgeLog().Info("no remote SKI configured, pair a remote via MQTT")
 	}
 
 	port = availablePort(cfg.Port)
 
-	configuration, err := api.NewConfiguration(
-		"eebus2mqtt", "eebus2mqtt", "HEMS", cfg.SN,
-		[]shipapi.DeviceCategoryType{shipapi.DeviceCategoryTypeEnergyManagementSystem},
-		model.DeviceTypeTypeEnergyManagementSystem,
-		[]model.EntityTypeType{model.EntityTypeTypeCEM},
-		port, certificate, time.Second*10)
+	configuration, info, err := newConfiguration(port)
 	if err != nil {
-		println("Error creating configuration:", err)
-		log.Fatal(err)
+		fatal("unable to configure the EEBUS service", "error", err)
 	}
-	configuration.SetAlternateIdentifier(fmt.Sprintf("eebus2mqtt-HEMS-%s", cfg.SN))
+	checkCertificate(info)
 
 	h.myService = service.NewService(configuration, h)
-	h.myService.SetLogging(h)
+	h.myService.SetLogging(logs.EEBUS())
 
 	if err = h.myService.Setup(); err != nil {
-		fmt.Println(err)
-		return
+		fatal("unable to set up the EEBUS service", "error", err)
 	}
 
 	localEntity := h.myService.LocalDevice().EntityForType(model.EntityTypeTypeCEM)
@@ -352,89 +114,113 @@ func (h *hems) run() {
 	h.myService.AddUseCase(h.uccslpc)
 	h.uccslpp = cslpp.NewLPP(localEntity, h.OnLPPEvent)
 	h.myService.AddUseCase(h.uccslpp)
-	// h.uceglpc = eglpc.NewLPC(localEntity, nil)
-	// h.myService.AddUseCase(h.uceglpc)
-	// h.uceglpp = eglpp.NewLPP(localEntity, nil)
-	// h.myService.AddUseCase(h.uceglpp)
+
+	// manual approvals may take longer than the default SPINE timeout
+	if lc := localEntity.FeatureOfTypeAndRole(model.FeatureTypeTypeLoadControl, model.RoleTypeServer); lc != nil {
+		lc.SetWriteApprovalTimeout(writeApprovalTimeout())
+	}
+	h.lppApprover = newLimitApprover("lpp",
+		func() hemsconfig.ApprovalPolicy { return hemsConfig().LPPApproval },
+		productionValue,
+		h.uccslpp.ApproveOrDenyProductionLimit)
+	h.lpcApprover = newLimitApprover("lpc",
+		func() hemsconfig.ApprovalPolicy { return hemsConfig().LPCApproval },
+		consumptionValue,
+		h.uccslpc.ApproveOrDenyConsumptionLimit)
 	h.ucmamgcp = mgcp.NewMGCP(localEntity, h.OnMGCPEvent)
 	h.myService.AddUseCase(h.ucmamgcp)
-	// h.uccemvabd = vabd.NewVABD(localEntity, h.OnVABDEvent)
-	// h.myService.AddUseCase(h.uccemvabd)
-	// h.uccemvapd = vapd.NewVAPD(localEntity, h.OnVAPDEvent)
-	// h.myService.AddUseCase(h.uccemvapd)
+	h.ucevsecc = evsecc.NewEVSECC(localEntity, h.OnEVSECCEvent)
+	h.myService.AddUseCase(h.ucevsecc)
 
 	// Initialize local server data
-	_ = h.uccslpc.SetConsumptionLimit(ucapi.LoadLimit{
-		Value:        4200,
+	lfs, lfd := failsafeSettings(&config.Hems.FailsafeValues.LPC, defaultLPCFailsafe, defaultLPCFailsafeDuration)
+	logInitError("lpc", "limit", h.uccslpc.SetConsumptionLimit(ucapi.LoadLimit{
+		Value:        float64(lpcNominalMax()),
 		IsChangeable: true,
 		IsActive:     false,
-	})
-	_ = h.uccslpc.SetFailsafeConsumptionActivePowerLimit(4200, true)
-	_ = h.uccslpc.SetFailsafeDurationMinimum(2*time.Hour, true)
+	}))
+	logInitError("lpc", "failsafe_limit", h.uccslpc.SetFailsafeConsumptionActivePowerLimit(float64(lfs), true))
+	logInitError("lpc", "failsafe_duration", h.uccslpc.SetFailsafeDurationMinimum(time.Duration(lfd)*time.Second, true))
+	logInitError("lpc", "nominal_max", h.uccslpc.SetConsumptionNominalMax(float64(lpcNominalMax())))
 
-	_ = h.uccslpp.SetProductionLimit(ucapi.LoadLimit{
+	logInitError("lpp", "limit", h.uccslpp.SetProductionLimit(ucapi.LoadLimit{
 		Value:        -1 * float64(cfg.PVMax),
 		IsChangeable: true,
 		IsActive:     false,
-	})
+	}))
 
-	fs, fd := getFailsafeLPP()
-	_ = h.uccslpp.SetFailsafeProductionActivePowerLimit(float64(fs), true)
-	_ = h.uccslpp.SetFailsafeDurationMinimum(time.Duration(fd)*time.Second, true)
-	_ = h.uccslpp.SetProductionNominalMax(float64(cfg.PVMax))
+	fs, fd := failsafeSettings(&config.Hems.FailsafeValues.LPP, defaultLPPFailsafe, defaultLPPFailsafeDuration)
+	logInitError("lpp", "failsafe_limit", h.uccslpp.SetFailsafeProductionActivePowerLimit(float64(fs), true))
+	logInitError("lpp", "failsafe_duration", h.uccslpp.SetFailsafeDurationMinimum(time.Duration(fd)*time.Second, true))
+	logInitError("lpp", "nominal_max", h.uccslpp.SetProductionNominalMax(float64(cfg.PVMax)))
 
-	client.Publish("eebus2mqtt/hems/lpp/allowed_production", 1, false, fmt.Sprintf("%d", fs))
-	client.Publish("eebus2mqtt/hems/lpp/limit_activ", 1, false, fmt.Sprintf("%.t", true))
-	WriteLog(logfile, StateInit, fmt.Sprintf("%.d W during int", fs))
+	h.lppState = limitstate.NewStateMachine(limitstate.LPPLimits(h.uccslpp), nil, h.onLPPStateChange)
+	h.lpcState = limitstate.NewStateMachine(limitstate.LPCLimits(h.uccslpc), nil, h.onLPCStateChange)
 
-	if len(remoteSki) == 0 {
-		fmt.Println("No Remote SKI provided, exiting.")
-		os.Exit(0)
-	}
+	client.Publish("eebus2mqtt/hems/lpp/allowed_production", qos, true, fmt.Sprintf("%d", fs))
+	publishLimitState("lpp", h.lppState.State())
+	publishLimitState("lpc", h.lpcState.State())
+	h.publishLPP()
+	h.publishLPC()
+	auditState(limitstate.StateInit, "LPP failsafe limit during init", "limit", fs)
 
-	h.myService.RegisterRemoteSKI(remoteSki, "")
-	// print("test ski ", h.myService.LocalService().SKI())
+	for _, remote := range remoteConfigs() {
+		h.myService.RegisterRemoteSKI(shiputil.NormalizeSKI(remote.SKI), remote.ShipID)
+		publishRemote(remote.SKI, "connected", "false")
+	}
 	h.myService.Start()
+	h.publishPairingInfo()
 
 }
 
-func getFailsafeLPP() (limit int, duration int) {
-
-	if config.Hems.KeyFile == "" {
-		//first run
-		limit = 4200
-		duration = 7200
-		config.Hems.Failsafe, _ = encryptPassword(strconv.Itoa(limit))
-		config.Hems.FailsafeDuration, _ = encryptPassword(strconv.Itoa(duration))
-		file, _ := os.Create(configfile)
-		encoder := json.NewEncoder(file)
-		encoder.SetIndent("", "  ")
-		_ = encoder.Encode(config)
-		file.Close()
-		return limit, duration
-	} else {
-		fs, err := decryptPassword(config.Hems.Failsafe)
-		if err != nil {
-			limit = 0
-		} else {
-			limit, err = strconv.Atoi(fs)
-			if err != nil {
-				limit = 0
-			}
+// return the service configuration with the certificate of the config
+func newConfiguration(port int) (*api.Configuration, certs.Info, error) {
+	cfg := config.Hems
 
-		}
-		fd, err := decryptPassword(config.Hems.FailsafeDuration)
-		if err != nil {
-			duration = 86400
-		} else {
-			duration, err = strconv.Atoi(fd)
-			if err != nil {
-				duration = 86400
-			}
+	// the certificate is set by certs.Apply
+	configuration, err := api.NewConfiguration(
+		"eebus2mqtt", "eebus2mqtt", "HEMS", cfg.SN,
+		[]shipapi.DeviceCategoryType{shipapi.DeviceCategoryTypeEnergyManagementSystem},
+		model.DeviceTypeTypeEnergyManagementSystem,
+		[]model.EntityTypeType{model.EntityTypeTypeCEM},
+		port, tls.Certificate{}, time.Second*10)
+	if err != nil {
+		return nil, certs.Info{}, fmt.Errorf("error creating configuration: %w", err)
+	}
 
-		}
-		return limit, duration
+	info, err := certs.Apply(configuration, certificatePair())
+	if err != nil {
+		return nil, certs.Info{}, fmt.Errorf("error loading certificate and key: %w", err)
+	}
+	configuration.SetAlternateIdentifier(fmt.Sprintf("eebus2mqtt-HEMS-%s", cfg.SN))
+
+	return configuration, info, nil
+}
+
+// log a value the use case did not accept during the initialization
+//
+// the config validation checks the values, an error is not expected
+func logInitError(usecase, name string, err error) {
+	if err != nil {
+		usecaseLog().Error("unable to set the initial value", "usecase", usecase, "value", name, "error", err)
+	}
+}
+
+// return the failsafe limit (W) and duration (s) of a use case from the config
+//
+// missing values are set to the defaults and saved
+func failsafeSettings(fs *hemsconfig.Failsafe, defaultLimit, defaultDuration int) (int, int) {
+	configMux.Lock()
+	defer configMux.Unlock()
+
+	if fs.Limit == nil || fs.Duration == nil {
+		// first run
+		fs.SetLimit(defaultLimit)
+		fs.SetDuration(defaultDuration)
+		saveConfigLocked()
 	}
+
+	return *fs.Limit, *fs.Duration
 }
 
 // Find available port
@@ -454,17 +240,30 @@ func availablePort(port int) (hemsport int) {
 	}
 	if !found {
 		// fallback to 0 to let the OS pick a free port
-		fmt.Println("Info: no free port in range, falling back to 0 (auto).")
+		bridgeLog().Info("no free port in range, falling back to 0 (auto)", "port", startPort)
 		port = 0
 	} else {
-		fmt.Println("Info: using port", hemsport)
+		bridgeLog().Info("using port", "port", hemsport)
 	}
 	return hemsport
 
 }
 
-func EKG(h *hems) {
+// publish the current LPP values
+func (h *hems) publishLPP() {
+	if nominalMax, err := h.uccslpp.ProductionNominalMax(); err == nil {
+		client.Publish("eebus2mqtt/hems/lpp/NominalMax", qos, true, fmt.Sprintf("%.f", nominalMax))
+	}
+	if currentLimit, _, err := h.uccslpp.FailsafeProductionActivePowerLimit(); err == nil {
+		client.Publish("eebus2mqtt/hems/lpp/failsafe_limit", qos, true, fmt.Sprintf("%.f", currentLimit))
+	}
+	if duration, _, err := h.uccslpp.FailsafeDurationMinimum(); err == nil {
+		client.Publish("eebus2mqtt/hems/lpp/failsafe_duration", qos, true, fmt.Sprintf("%.f", duration.Seconds()))
+	}
+}
 
+// LPP heartbeat and limit supervision
+func EKG(h *hems) {
 	if cancel != nil {
 		cancel() // signalisiert der laufenden Goroutine das Ende
 	}
@@ -472,155 +271,16 @@ func EKG(h *hems) {
 	var ctx context.Context
 	ctx, cancel = context.WithCancel(context.Background())
 
-	go func() {
-
-		ticker := time.NewTicker(1 * time.Second)
-		defer ticker.Stop()
-		limited_written := false
-		unlimited_written := false
-
-		allowedproduction, _ := h.uccslpp.ProductionNominalMax()
-
-		for {
-			select {
-
-			case <-ctx.Done(): // Stop-Signal
-				return
-
-			case <-ticker.C:
-				wait := time.Now()
-				since := wait.Sub(ekg)
-				client.Publish("eebus2mqtt/hems/lpp/last_heartbeat", 1, false, fmt.Sprintf("%.f", since.Seconds()))
-				client.Publish("eebus2mqtt/hems/lpp/allowed_production", 1, false, fmt.Sprintf("%.f", allowedproduction))
-				//fs, _, _ := h.uccslpp.FailsafeProductionActivePowerLimit()
-				//println("FS: ", fs)
-				if since.Seconds() > 120 && !isFailsafe {
-					l, _, _ := h.uccslpp.FailsafeProductionActivePowerLimit()
-					allowedproduction = l
-					isFailsafe = true
-
-					client.Publish("eebus2mqtt/hems/lpp/limit_activ", 1, false, fmt.Sprintf("%.t", isFailsafe))
-					WriteLog(logfile, StateFailsafe, fmt.Sprintf("%.0f W", allowedproduction))
-					go FailsafeCountdown(h)
-				}
-				if since.Seconds() <= 120 {
-					isFailsafe = false
-					productionlimit, _ := h.uccslpp.ProductionLimit()
-
-					if productionlimit.IsActive && productionlimit.Duration.Seconds() > 0 {
-						allowedproduction = -1 * productionlimit.Value
-						client.Publish("eebus2mqtt/hems/lpp/limit_activ", 1, false, fmt.Sprintf("%.t", productionlimit.IsActive))
-						client.Publish("eebus2mqtt/hems/lpp/LimitCountdown", 1, false, fmt.Sprintf("%.f", productionlimit.Duration.Seconds()))
-
-						if !limited_written {
-							WriteLog(logfile, StateLimited, fmt.Sprintf("%.0f W", allowedproduction))
-							limited_written = true
-							unlimited_written = false
-						}
-
-					} else {
-						if productionlimit.Duration.Seconds() < 1 {
-							productionlimit.IsActive = false
-
-							allowedproduction, _ = h.uccslpp.ProductionNominalMax()
-							client.Publish("eebus2mqtt/hems/lpp/limit_activ", 1, false, fmt.Sprintf("%.t", productionlimit.IsActive))
-							client.Publish("eebus2mqtt/hems/lpp/LimitCountdown", 1, false, fmt.Sprintf("%.f", productionlimit.Duration.Seconds()))
-
-							if !unlimited_written {
-								WriteLog(logfile, StateUnlimitedControlled)
-								limited_written = false
-								unlimited_written = true
-							}
-						}
-
-					}
-				}
-			}
-		}
+	h.publishLPP()
 
-	}()
+	go superviseLimits(ctx, h.lppState, "lpp", "allowed_production")
 }
 
-func FailsafeCountdown(h *hems) {
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-	d, _, _ := h.uccslpp.FailsafeDurationMinimum()
-	// d := 20 * time.Second
-	start := time.Now()
-	for range ticker.C {
-		wait := time.Now()
-		failsafe_time := wait.Sub(start)
-		if failsafe_time <= d && isFailsafe {
-			cd := d - failsafe_time
-			client.Publish("eebus2mqtt/hems/lpp/FailsafeCountdown", 1, false, fmt.Sprintf("%.f", cd.Seconds()))
-			client.Publish("eebus2mqtt/hems/lpp/limit_activ", 1, false, fmt.Sprintf("%.t", isFailsafe))
-
-		} else {
-			client.Publish("eebus2mqtt/hems/lpp/FailsafeCountdown", 1, false, fmt.Sprintf("%.f", d.Seconds()))
-			ticker.Stop()
-			// failsafe over
-			isFailsafe = false
-		}
-	}
-
-}
-
-// Controllable System LPC Event Handler
-// output only mqqt for now
-func (h *hems) OnLPCEvent(ski string, device spineapi.DeviceRemoteInterface, entity spineapi.EntityRemoteInterface, event api.EventType) {
-	// 	switch event {
-	// 	case cslpc.WriteApprovalRequired:
-	// 		// get pending writes
-	// 		pendingWrites := h.uccslpc.PendingConsumptionLimits()
-
-	// 		// approve any write
-	// 		for msgCounter, write := range pendingWrites {
-	// 			fmt.Println("Approving LPC write with msgCounter", msgCounter, "and limit", write.Value, "W")
-	// 			h.uccslpc.ApproveOrDenyConsumptionLimit(msgCounter, true, "")
-	// 		}
-	// 	case cslpc.DataUpdateLimit:
-	// 		if currentLimit, err := h.uccslpc.ConsumptionLimit(); err == nil {
-	// 			//			fmt.Println("New LPC Limit set to", currentLimit.Value, "W. Is Active:", currentLimit.IsActive)
-	// 			client.Publish("eebus2mqtt/hems/lpc/limit", 1, false, fmt.Sprintf("%.f", currentLimit.Value))
-	// 			client.Publish("eebus2mqtt/hems/lpc/active", 1, false, fmt.Sprintf("%t", currentLimit.IsActive))
-	// 		}
-	// 	case cslpc.DataUpdateHeartbeat:
-	// 		// publish everything on heartbeat
-	// 		cnm, _ := h.uccslpc.ConsumptionNominalMax()
-	// 		client.Publish("eebus2mqtt/hems/lpc/NominalMax", 1, false, fmt.Sprintf("%.f", cnm))
-	// 		if currentLimit, err := h.uccslpc.ConsumptionLimit(); err == nil {
-
-	// 			client.Publish("eebus2mqtt/hems/lpc/limit", 1, false, fmt.Sprintf("%.f", currentLimit.Value))
-	// 			client.Publish("eebus2mqtt/hems/lpc/active", 1, false, fmt.Sprintf("%t", currentLimit.IsActive))
-	// 		}
-	// 		if currentLimit, isChangeable, err := h.uccslpc.FailsafeConsumptionActivePowerLimit(); err == nil {
-	// 			//			fmt.Println("New LPP Failsafe Production Active Power Limit set to", currentLimit, "W")
-	// 			//			fmt.Println("Is Changeable:", isChangeable)
-	// 			client.Publish("eebus2mqtt/hems/lpc/failsafe_limit", 1, false, fmt.Sprintf("%.f", currentLimit))
-	// 			client.Publish("eebus2mqtt/hems/lpc/failsafe_changeable", 1, false, fmt.Sprintf("%t", isChangeable))
-	// 		}
-	// 		if duration, isChangeable, err := h.uccslpc.FailsafeDurationMinimum(); err == nil {
-	// 			//			fmt.Println("New LPC Failsafe Duration Minimum set to", duration)
-	// 			//			fmt.Println("Is Changeable:", isChangeable)
-	// 			client.Publish("eebus2mqtt/hems/lpc/failsafe_duration", 1, false, fmt.Sprintf("%.f", duration.Seconds()))
-	// 			client.Publish("eebus2mqtt/hems/lpc/failsafe_changeable", 1, false, fmt.Sprintf("%t", isChangeable))
-	// 		}
-	// 	case cslpc.DataUpdateFailsafeConsumptionActivePowerLimit:
-	// 		if currentLimit, isChangeable, err := h.uccslpc.FailsafeConsumptionActivePowerLimit(); err == nil {
-	// 			//			fmt.Println("New LPP Failsafe Production Active Power Limit set to", currentLimit, "W")
-	// 			//			fmt.Println("Is Changeable:", isChangeable)
-	// 			client.Publish("eebus2mqtt/hems/lpc/failsafe_limit", 1, false, fmt.Sprintf("%.f", currentLimit))
-	// 			client.Publish("eebus2mqtt/hems/lpc/failsafe_changeable", 1, false, fmt.Sprintf("%t", isChangeable))
-	// 		}
-	// 	case cslpc.DataUpdateFailsafeDurationMinimum:
-	// 		if duration, isChangeable, err := h.uccslpc.FailsafeDurationMinimum(); err == nil {
-	// 			//			fmt.Println("New LPC Failsafe Duration Minimum set to", duration)
-	// 			//			fmt.Println("Is Changeable:", isChangeable)
-	// 			client.Publish("eebus2mqtt/hems/lpc/failsafe_duration", 1, false, fmt.Sprintf("%.f", duration.Seconds()))
-	// 			client.Publish("eebus2mqtt/hems/lpc/failsafe_changeable", 1, false, fmt.Sprintf("%t", isChangeable))
-	// 		}
-
-	// 	}
+// log and publish LPP state changes
+func (h *hems) onLPPStateChange(from, to limitstate.State) {
+	value, _ := h.lppState.ActivePowerLimit()
+	publishLimitState("lpp", to)
+	auditState(to, "LPP state changed", "from", from.String(), "limit", value)
 }
 
 // Controllable System LPP Event Handler
@@ -628,132 +288,24 @@ func (h *hems) OnLPCEvent(ski string, device spineapi.DeviceRemoteInterface, ent
 func (h *hems) OnLPPEvent(ski string, device spineapi.DeviceRemoteInterface, entity spineapi.EntityRemoteInterface, event api.EventType) {
 	switch event {
 	case cslpp.WriteApprovalRequired:
-		// get pending writes
-		pendingWrites := h.uccslpp.PendingProductionLimits()
-
-		// approve any write
-		for msgCounter, write := range pendingWrites {
-			fmt.Println("Approving LPP write with msgCounter", msgCounter, "and limit", write.Value, "W")
-
-			fmt.Println("LPP msgCounter", msgCounter, " limit", write.Value, " duration ", write.Duration, " Active: ", write.IsActive)
-
-			if write.IsActive {
-				switch {
-				case write.Duration == 0:
-					h.uccslpp.ApproveOrDenyProductionLimit(msgCounter, false, "Duration zero")
-					WriteLog(logfile, Info, fmt.Sprintf("Msg %d: Production Limit denied: Duration zero.", msgCounter))
-
-				case write.Value > 0:
-					h.uccslpp.ApproveOrDenyProductionLimit(msgCounter, false, "Value > 0")
-					WriteLog(logfile, Info, fmt.Sprintf("Msg %d: Production Limit denied: Value > 0.", msgCounter))
-
-				default:
-					h.uccslpp.ApproveOrDenyProductionLimit(msgCounter, true, "")
-				}
-			} else {
-				if write.Value > 0 {
-					h.uccslpp.ApproveOrDenyProductionLimit(msgCounter, false, "Value > 0")
-					WriteLog(logfile, Info, fmt.Sprintf("Msg %d: Production Limit denied: Value > 0.", msgCounter))
-				} else {
-					h.uccslpp.ApproveOrDenyProductionLimit(msgCounter, true, "")
-					WriteLog(logfile, StateUnlimitedControlled)
-				}
-
-			}
-
-		}
+		h.lppApprover.handle(ski, h.uccslpp.PendingProductionLimits())
 
 	case cslpp.DataUpdateLimit:
 		if currentLimit, err := h.uccslpp.ProductionLimit(); err == nil {
-			fmt.Println("New LPP Limit set to", currentLimit.Value, "W. Is Active:", currentLimit.IsActive)
-			// client.Publish("eebus2mqtt/hems/lpp/limit", 1, false, fmt.Sprintf("%.f", currentLimit.Value))
-			// client.Publish("eebus2mqtt/hems/lpp/active", 1, false, fmt.Sprintf("%t", currentLimit.IsActive))
+			usecaseLog().Info("new LPP limit", "limit", currentLimit.Value, "active", currentLimit.IsActive)
+			h.lppState.LimitUpdated()
 		}
 	case cslpp.DataUpdateHeartbeat:
-		ekg = time.Now()
-	// 	// publish everything on heartbeat
-	// 	pnm, _ := h.uccslpp.ProductionNominalMax()
-	// 	client.Publish("eebus2mqtt/hems/lpp/NominalMax", 1, false, fmt.Sprintf("%.f", pnm))
-	// 	if currentLimit, err := h.uccslpp.ProductionLimit(); err == nil {
-	// 		client.Publish("eebus2mqtt/hems/lpp/limit", 1, false, fmt.Sprintf("%.f", currentLimit.Value))
-	// 		client.Publish("eebus2mqtt/hems/lpp/active", 1, false, fmt.Sprintf("%t", currentLimit.IsActive))
-	// 	}
-	// 	if currentLimit, isChangeable, err := h.uccslpp.FailsafeProductionActivePowerLimit(); err == nil {
-	// 		fmt.Println("New LPP Failsafe Production Active Power Limit set to", currentLimit, "W")
-	// 		fmt.Println("Is Changeable:", isChangeable)
-	// 		client.Publish("eebus2mqtt/hems/lpp/failsafe_limit", 1, false, fmt.Sprintf("%.f", currentLimit))
-	// 		client.Publish("eebus2mqtt/hems/lpp/failsafe_changeable", 1, false, fmt.Sprintf("%t", isChangeable))
-	// 	}
-	// 	if duration, isChangeable, err := h.uccslpp.FailsafeDurationMinimum(); err == nil {
-	// 		fmt.Println("New LPP Failsafe Duration Minimum set to", duration)
-	// 		fmt.Println("Is Changeable:", isChangeable)get
-	// 		client.Publish("eebus2mqtt/hems/lpp/failsafe_duration", 1, false, fmt.Sprintf("%.f", duration.Seconds()))
-	// 		client.Publish("eebus2mqtt/hems/lpp/failsafe_changeable", 1, false, fmt.Sprintf("%t", isChangeable))
-	// 	}
+		h.lppState.Heartbeat()
 	case cslpp.DataUpdateFailsafeProductionActivePowerLimit:
 		if currentLimit, _, err := h.uccslpp.FailsafeProductionActivePowerLimit(); err == nil {
-			fmt.Println("New LPP Failsafe Production Active Power Limit set to", currentLimit, "W")
-			failsafe := int(currentLimit)
-			config.Hems.Failsafe, _ = encryptPassword(strconv.Itoa(failsafe))
-			file, _ := os.Create(configfile)
-			encoder := json.NewEncoder(file)
-			encoder.SetIndent("", "  ")
-			_ = encoder.Encode(config)
-			file.Close()
-			// fmt.Println("Is Changeable:", isChangeable)
-			// client.Publish("eebus2mqtt/hems/lpp/failsafe_limit", 1, false, fmt.Sprintf("%.f", currentLimit))
-			// client.Publish("eebus2mqtt/hems/lpp/failsafe_changeable", 1, false, fmt.Sprintf("%t", isChangeable))
+			usecaseLog().Info("new LPP failsafe production active power limit", "limit", currentLimit)
+			updateConfig(func() { config.Hems.FailsafeValues.LPP.SetLimit(int(currentLimit)) })
 		}
 	case cslpp.DataUpdateFailsafeDurationMinimum:
 		if duration, _, err := h.uccslpp.FailsafeDurationMinimum(); err == nil {
-			fmt.Println("New LPP Failsafe Duration Minimum set to", duration)
-			fd := int(duration.Seconds())
-			config.Hems.FailsafeDuration, _ = encryptPassword(strconv.Itoa(fd))
-			file, _ := os.Create(configfile)
-			encoder := json.NewEncoder(file)
-			encoder.SetIndent("", "  ")
-			_ = encoder.Encode(config)
-			file.Close()
-
-			// fmt.Println("Is Changeable:", isChangeable)
-			// client.Publish("eebus2mqtt/hems/lpp/failsafe_duration", 1, false, fmt.Sprintf("%.f", duration.Seconds()))
-			// client.Publish("eebus2mqtt/hems/lpp/failsafe_changeable", 1, false, fmt.Sprintf("%t", isChangeable))
-		}
-
-	}
-}
-
-// Monitoring Appliance MGCP Event Handler
-
-func (h *hems) OnMGCPEvent(ski string, device spineapi.DeviceRemoteInterface, entity spineapi.EntityRemoteInterface, event api.EventType) {
-	switch event {
-	case mgcp.DataUpdatePowerLimitationFactor:
-		if factor, err := h.ucmamgcp.PowerLimitationFactor(entity); err == nil {
-			fmt.Println("New MGCP Power Limitation Factor set to", factor)
-		}
-	case mgcp.DataUpdatePower:
-		if power, err := h.ucmamgcp.Power(entity); err == nil {
-			fmt.Println("New MGCP Power set to", power, "W")
-		}
-	case mgcp.DataUpdateEnergyFeedIn:
-		if energy, err := h.ucmamgcp.EnergyFeedIn(entity); err == nil {
-			fmt.Println("New MGCP Energy Feed-In set to", energy, "Wh")
-		}
-	case mgcp.DataUpdateEnergyConsumed:
-		if energy, err := h.ucmamgcp.EnergyConsumed(entity); err == nil {
-			fmt.Println("New MGCP Energy Consumed set to", energy, "Wh")
-		}
-	case mgcp.DataUpdateCurrentPerPhase:
-		if current, err := h.ucmamgcp.CurrentPerPhase(entity); err == nil {
-			fmt.Println("New MGCP Current per Phase set to", current, "A")
-		}
-	case mgcp.DataUpdateVoltagePerPhase:
-		if voltage, err := h.ucmamgcp.VoltagePerPhase(entity); err == nil {
-			fmt.Println("New MGCP Voltage per Phase set to", voltage, "V")
-		}
-	case mgcp.DataUpdateFrequency:
-		if frequency, err := h.ucmamgcp.Frequency(entity); err == nil {
-			fmt.Println("New MGCP Frequency set to", frequency, "Hz")
+			usecaseLog().Info("new LPP failsafe duration minimum", "duration", duration)
+			updateConfig(func() { config.Hems.FailsafeValues.LPP.SetDuration(int(duration.Seconds())) })
 		}
 	}
 }
@@ -761,189 +313,145 @@ func (h *hems) OnMGCPEvent(ski string, device spineapi.DeviceRemoteInterface, en
 // EEBUSServiceHandler
 
 func (h *hems) RemoteSKIConnected(service api.ServiceInterface, ski string) {
-	cfg := config.Hems
+	setRemoteConnected(ski, true)
+
+	// remember the local SKI to detect a rotated certificate
+	updateRemoteLocalSKI(ski, service.LocalService().SKI())
+	publishRemote(ski, "pair_again", "false")
+
+	cfg := hemsConfig()
 	time.AfterFunc(3*time.Second, func() {
-		_ = h.uccslpc.SetConsumptionNominalMax(32000)
-		_ = h.uccslpp.SetProductionNominalMax(float64(cfg.PVMax))
-		println("starte ekg")
+		logInitError("lpc", "nominal_max", h.uccslpc.SetConsumptionNominalMax(float64(lpcNominalMax())))
+		logInitError("lpp", "nominal_max", h.uccslpp.SetProductionNominalMax(float64(cfg.PVMax)))
+		usecaseLog().Debug("starting the heartbeat supervision", "ski", ski)
 
 		EKG(h)
+		EKGLPC(h)
 	})
 }
 
-func (h *hems) RemoteSKIDisconnected(service api.ServiceInterface, ski string) {}
+func (h *hems) RemoteSKIDisconnected(service api.ServiceInterface, ski string) {
+	setRemoteConnected(ski, false)
+}
 
 func (h *hems) VisibleRemoteServicesUpdated(service api.ServiceInterface, entries []shipapi.RemoteService) {
+	visibleMux.Lock()
+	visibleServices = entries
+	visibleMux.Unlock()
+
+	publishVisibleServices()
 }
 
 func (h *hems) ServiceShipIDUpdate(ski string, shipdID string) {
-	println("shipID:", shipdID)
+	bridgeLog().Debug("SHIP ID updated", "ski", ski, "ship_id", shipdID)
+
+	// the SHIP ID is needed to reconnect to the remote without mDNS
+	updateRemoteShipID(ski, shipdID)
+	publishRemote(ski, "ship_id", shipdID)
 }
 
 func (h *hems) ServicePairingDetailUpdate(ski string, detail *shipapi.ConnectionStateDetail) {
-	log.Printf("🤝 Pairing update for %s: state=%d", ski, detail.State())
+	bridgeLog().Info("pairing update", "ski", ski, "state", pairingStateName(detail.State()))
+
+	publishPairingState(ski, detail)
 
-	if ski == remoteSki && detail.State() == shipapi.ConnectionStateRemoteDeniedTrust {
-		fmt.Println("The remote service denied trust. Exiting.")
+	if _, ok := findRemote(ski); !ok {
+		return
+	}
+
+	publishRemote(ski, "pairing_state", pairingStateName(detail.State()))
+
+	// keep serving the other remotes, the pairing can be retried after a restart
+	if detail.State() == shipapi.ConnectionStateRemoteDeniedTrust {
 		h.myService.CancelPairingWithSKI(ski)
 		h.myService.UnregisterRemoteSKI(ski)
-		h.myService.Shutdown()
-		os.Exit(0)
+		auditEvent("remote denied trust, pairing cancelled", "ski", ski)
 	}
 }
 
 func (h *hems) AllowWaitingForTrust(ski string) bool {
-	return ski == remoteSki
+	_, ok := findRemote(ski)
+	return ok
 }
 
-// UCEvseCommisioningConfigurationCemDelegate
+// EVSE Commissioning and Configuration EVSECC Event Handler
 
-// handle device state updates from the remote EVSE device
-func (h *hems) HandleEVSEDeviceState(ski string, failure bool, errorCode string) {
-	fmt.Println("EVSE Error State:", failure, errorCode)
+func (h *hems) OnEVSECCEvent(ski string, device spineapi.DeviceRemoteInterface, entity spineapi.EntityRemoteInterface, event api.EventType) {
+	switch event {
+	case evsecc.DataUpdateManufacturerData:
+		if data, err := h.ucevsecc.ManufacturerData(entity); err == nil {
+			usecaseLog().Info("EVSE manufacturer data", "brand", data.BrandName, "device", data.DeviceName, "serial_number", data.SerialNumber)
+		}
+	case evsecc.EvseFailureEntered, evsecc.EvseFailureCleared:
+		if state, errorCode, err := h.ucevsecc.OperatingState(entity); err == nil {
+			h.HandleEVSEDeviceState(ski, state == model.DeviceDiagnosisOperatingStateTypeFailure, errorCode)
+		}
+	}
 }
 
-var messagePubHandler mqtt.MessageHandler = func(client mqtt.Client, msg mqtt.Message) {
-	// fmt.Printf("Received message: %s from topic: %s\n", msg.Payload(), msg.Topic())
-}
-var connectHandler mqtt.OnConnectHandler = func(client mqtt.Client) {
-	fmt.Println("MQTT Connected")
-	sub(client)
+// handle device state updates from the remote EVSE device
+func (h *hems) HandleEVSEDeviceState(ski string, failure bool, errorCode string) {
+	usecaseLog().Info("EVSE error state", "ski", ski, "failure", failure, "error_code", errorCode)
 }
 
-var connectLostHandler mqtt.ConnectionLostHandler = func(client mqtt.Client, err error) {
-	fmt.Printf("MQTT Connect lost: %v", err)
-}
+// change the config and save it while holding configMux
+func updateConfig(change func()) {
+	configMux.Lock()
+	defer configMux.Unlock()
 
-func sub(client mqtt.Client) {
-	topic := "eebus2mqtt/hems/#"
-	token := client.Subscribe(topic, 1, nil)
-	token.Wait()
-	fmt.Printf("Subscribed to topic: %s\n", topic)
+	change()
+	saveConfigLocked()
 }
 
-func mqttConnect() {
-	var broker = config.Mqtt.Broker
-	var port = config.Mqtt.Port
-	if len(config.Mqtt.Password) < 25 {
-		config.Mqtt.Password, _ = encryptPassword(config.Mqtt.Password)
-
-		file, _ := os.Create(configfile)
-		encoder := json.NewEncoder(file)
-		encoder.SetIndent("", "  ")
-		_ = encoder.Encode(config)
-		file.Close()
-	}
-	pw, _ := decryptPassword(config.Mqtt.Password)
-
-	opts := mqtt.NewClientOptions()
-	opts.AddBroker(fmt.Sprintf("tcp://%s:%d", broker, port))
-	opts.SetClientID(fmt.Sprintf("eebus2mqtt-hems-%d", time.Now().UnixNano()))
-	opts.SetUsername(config.Mqtt.Username)
-	opts.SetPassword(pw)
-	opts.SetDefaultPublishHandler(messagePubHandler)
-	opts.OnConnect = connectHandler
-	opts.OnConnectionLost = connectLostHandler
-	client = mqtt.NewClient(opts)
-	if token := client.Connect(); token.Wait() && token.Error() != nil {
-		println("MQTT failed:", token.Error())
+// write the config file, configMux must be held
+func saveConfigLocked() {
+	if err := store.Save(config); err != nil {
+		bridgeLog().Error("unable to write the config", "path", store.Path(), "error", err)
 	}
 }
 
-func encryptPassword(plaintext string) (string, error) {
-	aead, _ := getKey() // liefert cipher.AEAD
-	nonceSize := aead.NonceSize()
-
-	// Nonce erzeugen
-	nonce := make([]byte, nonceSize)
-	if _, err := rand.Read(nonce); err != nil {
-		return "", err
-	}
+// return a copy of the HEMS settings
+func hemsConfig() hemsconfig.Hems {
+	configMux.Lock()
+	defer configMux.Unlock()
 
-	// Verschlüsseln
-	ciphertext := aead.Seal(nil, nonce, []byte(plaintext), nil)
-
-	// nonce + ciphertext als Base64 zurückgeben
-	out := append(nonce, ciphertext...)
-	return base64.StdEncoding.EncodeToString(out), nil
+	return config.Hems
 }
 
-func decryptPassword(encryptedBase64 string) (string, error) {
-	data, err := base64.StdEncoding.DecodeString(encryptedBase64)
-	if err != nil {
-		return "", err
-	}
+// return a copy of the MQTT config
+func mqttConfig() hemsconfig.Mqtt {
+	configMux.Lock()
+	defer configMux.Unlock()
 
-	aead, _ := getKey() // liefert cipher.AEAD
-	nonceSize := aead.NonceSize()
-
-	if len(data) < nonceSize {
-		return "", fmt.Errorf("ciphertext too short")
-	}
-
-	nonce := data[:nonceSize]
-	ciphertext := data[nonceSize:]
+	return config.Mqtt
+}
 
-	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
-	if err != nil {
-		return "", err
-	}
+// main app
+func main() {
+	flags := hemsconfig.Flags(flag.CommandLine)
+	flag.Usage = usage
+	flag.Parse()
 
-	return string(plaintext), nil
+	os.Exit(runCommand(flag.Args(), hemsconfig.Env(), flags))
 }
 
-func getKey() (cipher.AEAD, error) {
-
-	serial := config.Hems.SN
-	salt := []byte(serial)
-	pepper := []byte("ebus2mqtt")
-	// Prüfung: genau 10 Ziffern
-	ok, _ := regexp.MatchString(`^\d{10}$`, serial)
-	if !ok {
-		return nil, errors.New("serial_number must be exactly 10 digits")
-	}
-	if len(salt) < 8 {
-		return nil, errors.New("salt too short; recommend 16+ bytes")
+// run the bridge until it is stopped
+func runBridge() int {
+	if err := setupLogging(true); err != nil {
+		fatal("unable to set up logging", "error", err)
 	}
+	defer auditFile.Close()
 
-	// Argon2id parameter (empfohlen: an deine Umgebung anpassen)
-	var (
-		time    uint32 = 3         // Iterations
-		memory  uint32 = 64 * 1024 // 64 MB in KiB
-		threads uint8  = 4         // parallelism
-		keyLen         = 32        // 32 bytes = 256 bit
-	)
-
-	// combine salt and pepper for KDF salt input (pepper can be nil)
-	kdfSalt := make([]byte, len(salt)+len(pepper))
-	copy(kdfSalt, salt)
-	copy(kdfSalt[len(salt):], pepper)
-
-	// Argon2id derivation
-	key := argon2.IDKey([]byte(serial), kdfSalt, time, memory, threads, uint32(keyLen))
-
-	// Erzeuge AES-256 block cipher
-	block, err := aes.NewCipher(key)
-	// sobald key nicht mehr gebraucht wird, überschreiben (best effort)
-	for i := range key {
-		key[i] = 0
-	}
-	if err != nil {
-		return nil, err
-	}
+	ensureCertificate()
 
-	// Erzeuge GCM AEAD
-	aead, err := cipher.NewGCM(block)
-	if err != nil {
-		return nil, err
+	auditState(limitstate.StateInit, "bridge started")
+	if err := setupSecrets(); err != nil {
+		fatal("unable to set up the secret store", "error", err)
 	}
-	return aead, nil
-}
 
-// main app
-func main() {
-	mqttConnect()
+	started = time.Now()
 	h := hems{}
+	mqttConnect(&h)
 	h.run()
 
 	// Clean exit to make sure mdns shutdown is invoked
@@ -951,60 +459,8 @@ func main() {
 	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
 	<-sig
 	// User exit
-}
-
-// Logging interface
-
-func (h *hems) Trace(args ...interface{}) {
-	if len(os.Args) > 1 && os.Args[1] == "debug" {
-		h.print("TRACE", args...)
-	}
-}
-
-func (h *hems) Tracef(format string, args ...interface{}) {
-	if len(os.Args) > 1 && os.Args[1] == "debug" {
-		h.printFormat("TRACE", format, args...)
-	}
-}
-
-func (h *hems) Debug(args ...interface{}) {
-	if len(os.Args) > 1 && os.Args[1] == "debug" {
-		h.print("DEBUG", args...)
-	}
-}
-
-func (h *hems) Debugf(format string, args ...interface{}) {
-	if len(os.Args) > 1 && os.Args[1] == "debug" {
-		h.printFormat("DEBUG", format, args...)
-	}
-}
-
-func (h *hems) Info(args ...interface{}) {
-	h.print("INFO ", args...)
-}
-
-func (h *hems) Infof(format string, args ...interface{}) {
-	h.printFormat("INFO ", format, args...)
-}
-
-func (h *hems) Error(args ...interface{}) {
-	h.print("ERROR", args...)
-}
-
-func (h *hems) Errorf(format string, args ...interface{}) {
-	h.printFormat("ERROR", format, args...)
-}
-
-func (h *hems) currentTimestamp() string {
-	return time.Now().Format("2006-01-02 15:04:05")
-}
-
-func (h *hems) print(msgType string, args ...interface{}) {
-	value := fmt.Sprintln(args...)
-	fmt.Printf("%s %s %s", h.currentTimestamp(), msgType, value)
-}
+	client.Publish(availabilityTopic, qos, true, payloadOffline).WaitTimeout(time.Second)
+	client.Disconnect(250)
 
-func (h *hems) printFormat(msgType, format string, args ...interface{}) {
-	value := fmt.Sprintf(format, args...)
-	fmt.Println(h.currentTimestamp(), msgType, value)
+	return 0
 }
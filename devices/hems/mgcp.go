@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/usecases/ma/mgcp"
+	spineapi "github.com/enbility/spine-go/api"
+)
+
+// MGCP values are published per remote entity below
+// eebus2mqtt/hems/mgcp/<remote name>/<entity address>/, the combined state as JSON to .../state
+const mgcpTopic = "mgcp"
+
+// phase names used in the per phase topics
+var mgcpPhases = []string{"L1", "L2", "L3"}
+
+// last known MGCP values of a remote entity
+type mgcpState struct {
+	PowerLimitationFactor *float64  `json:"power_limitation_factor,omitempty"`
+	Power                 *float64  `json:"power,omitempty"`
+	EnergyFeedIn          *float64  `json:"energy_feed_in,omitempty"`
+	EnergyConsumed        *float64  `json:"energy_consumed,omitempty"`
+	CurrentPerPhase       []float64 `json:"current_per_phase,omitempty"`
+	VoltagePerPhase       []float64 `json:"voltage_per_phase,omitempty"`
+	Frequency             *float64  `json:"frequency,omitempty"`
+}
+
+var mgcpMux sync.Mutex
+var mgcpStates = make(map[string]*mgcpState)
+
+// return the topic path identifying a remote entity
+func mgcpEntityPath(ski string, entity spineapi.EntityRemoteInterface) string {
+	var address []string
+	if entity != nil && entity.Address() != nil {
+		for _, item := range entity.Address().Entity {
+			address = append(address, fmt.Sprintf("%d", item))
+		}
+	}
+	if len(address) == 0 {
+		address = []string{"0"}
+	}
+
+	return mgcpTopic + "/" + remoteName(ski) + "/" + strings.Join(address, "-")
+}
+
+// return the Home Assistant entities of a remote MGCP entity
+func mgcpDiscoveryEntities(path string) []discoveryEntity {
+	id := strings.ReplaceAll(path, "/", "_")
+
+	entities := []discoveryEntity{
+		sensor(id+"_power", "Grid power", path+"/power", "power", "W"),
+		energySensor(id+"_energy_feed_in", "Grid energy feed-in", path+"/energy_feed_in"),
+		energySensor(id+"_energy_consumed", "Grid energy consumed", path+"/energy_consumed"),
+		sensor(id+"_frequency", "Grid frequency", path+"/frequency", "frequency", "Hz"),
+		sensor(id+"_power_limitation_factor", "Grid power limitation factor", path+"/power_limitation_factor", "", "%"),
+	}
+	for _, phase := range mgcpPhases {
+		entities = append(entities,
+			sensor(id+"_current_"+strings.ToLower(phase), "Grid current "+phase, path+"/current/"+phase, "current", "A"),
+			sensor(id+"_voltage_"+strings.ToLower(phase), "Grid voltage "+phase, path+"/voltage/"+phase, "voltage", "V"),
+		)
+	}
+
+	return entities
+}
+
+// return the Home Assistant entities of all known remote MGCP entities
+func knownMGCPDiscoveryEntities() []discoveryEntity {
+	mgcpMux.Lock()
+	defer mgcpMux.Unlock()
+
+	var entities []discoveryEntity
+	for path := range mgcpStates {
+		entities = append(entities, mgcpDiscoveryEntities(path)...)
+	}
+	return entities
+}
+
+// update the cached state of a remote entity and publish the combined state
+func publishMGCPState(path string, update func(state *mgcpState)) {
+	mgcpMux.Lock()
+	state, ok := mgcpStates[path]
+	if !ok {
+		state = &mgcpState{}
+		mgcpStates[path] = state
+	}
+	update(state)
+	payload, err := json.Marshal(state)
+	mgcpMux.Unlock()
+
+	if !ok {
+		publishDiscoveryEntities(client, mgcpDiscoveryEntities(path))
+	}
+	if err == nil {
+		client.Publish(topicPrefix+path+"/state", qos, true, payload)
+	}
+}
+
+// publish a single MGCP value
+func publishMGCPValue(path, name string, value float64, retained bool) {
+	client.Publish(topicPrefix+path+"/"+name, qos, retained, fmt.Sprintf("%.2f", value))
+}
+
+// publish MGCP per phase values
+func publishMGCPPhaseValues(path, name string, values []float64) {
+	for index, value := range values {
+		if index >= len(mgcpPhases) {
+			break
+		}
+		publishMGCPValue(path, name+"/"+mgcpPhases[index], value, false)
+	}
+}
+
+// Monitoring Appliance MGCP Event Handler
+
+func (h *hems) OnMGCPEvent(ski string, device spineapi.DeviceRemoteInterface, entity spineapi.EntityRemoteInterface, event api.EventType) {
+	path := mgcpEntityPath(ski, entity)
+
+	switch event {
+	case mgcp.DataUpdatePowerLimitationFactor:
+		if factor, err := h.ucmamgcp.PowerLimitationFactor(entity); err == nil {
+			publishMGCPValue(path, "power_limitation_factor", factor, true)
+			publishMGCPState(path, func(state *mgcpState) { state.PowerLimitationFactor = &factor })
+		}
+	case mgcp.DataUpdatePower:
+		if power, err := h.ucmamgcp.Power(entity); err == nil {
+			publishMGCPValue(path, "power", power, false)
+			publishMGCPState(path, func(state *mgcpState) { state.Power = &power })
+		}
+	case mgcp.DataUpdateEnergyFeedIn:
+		if energy, err := h.ucmamgcp.EnergyFeedIn(entity); err == nil {
+			publishMGCPValue(path, "energy_feed_in", energy, true)
+			publishMGCPState(path, func(state *mgcpState) { state.EnergyFeedIn = &energy })
+		}
+	case mgcp.DataUpdateEnergyConsumed:
+		if energy, err := h.ucmamgcp.EnergyConsumed(entity); err == nil {
+			publishMGCPValue(path, "energy_consumed", energy, true)
+			publishMGCPState(path, func(state *mgcpState) { state.EnergyConsumed = &energy })
+		}
+	case mgcp.DataUpdateCurrentPerPhase:
+		if current, err := h.ucmamgcp.CurrentPerPhase(entity); err == nil {
+			publishMGCPPhaseValues(path, "current", current)
+			publishMGCPState(path, func(state *mgcpState) { state.CurrentPerPhase = current })
+		}
+	case mgcp.DataUpdateVoltagePerPhase:
+		if voltage, err := h.ucmamgcp.VoltagePerPhase(entity); err == nil {
+			publishMGCPPhaseValues(path, "voltage", voltage)
+			publishMGCPState(path, func(state *mgcpState) { state.VoltagePerPhase = voltage })
+		}
+	case mgcp.DataUpdateFrequency:
+		if frequency, err := h.ucmamgcp.Frequency(entity); err == nil {
+			publishMGCPValue(path, "frequency", frequency, false)
+			publishMGCPState(path, func(state *mgcpState) { state.Frequency = &frequency })
+		}
+	}
+}
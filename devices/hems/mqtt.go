@@ -0,0 +1,265 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+)
+
+// MQTT connection defaults
+const (
+	defaultMqttKeepAlive = 30
+	defaultMqttQoS       = 1
+	defaultMqttWSPath    = "/mqtt"
+)
+
+// QoS used for all publications and subscriptions
+var qos byte = defaultMqttQoS
+
+// messages without a subscription are ignored, the commands have their own handler
+var messagePubHandler mqtt.MessageHandler = func(client mqtt.Client, msg mqtt.Message) {
+}
+
+var connectLostHandler mqtt.ConnectionLostHandler = func(client mqtt.Client, err error) {
+	mqttLog().Warn("connection lost", "error", err)
+	logs.Audit().Info("MQTT connection lost", "error", err)
+}
+
+var reconnectingHandler mqtt.ReconnectHandler = func(client mqtt.Client, opts *mqtt.ClientOptions) {
+	mqttLog().Info("reconnecting")
+}
+
+func sub(client mqtt.Client, handler mqtt.MessageHandler) {
+	topic := commandTopicFilter
+	token := client.Subscribe(topic, qos, handler)
+	token.Wait()
+	if err := token.Error(); err != nil {
+		mqttLog().Error("subscription failed", "topic", topic, "error", err)
+		return
+	}
+	mqttLog().Debug("subscribed", "topic", topic)
+}
+
+// return the broker URL
+//
+// the broker may be a host name or a complete URL like wss://broker:443/mqtt
+func mqttBrokerURL(cfg hemsconfig.Mqtt) (string, error) {
+	if cfg.Broker == "" {
+		return "", errors.New("no MQTT broker configured")
+	}
+
+	if strings.Contains(cfg.Broker, "://") {
+		if _, err := url.Parse(cfg.Broker); err != nil {
+			return "", fmt.Errorf("invalid MQTT broker URL: %w", err)
+		}
+		return cfg.Broker, nil
+	}
+
+	scheme, port := "tcp", 1883
+	switch strings.ToLower(cfg.Scheme) {
+	case "", "tcp", "mqtt":
+	case "ssl", "tls", "mqtts":
+		scheme, port = "ssl", 8883
+	case "ws":
+		scheme, port = "ws", 80
+	case "wss":
+		scheme, port = "wss", 443
+	default:
+		return "", fmt.Errorf("unsupported MQTT scheme %q, use tcp, ssl, ws or wss", cfg.Scheme)
+	}
+	if cfg.Port > 0 {
+		port = cfg.Port
+	}
+
+	brokerURL := fmt.Sprintf("%s://%s:%d", scheme, cfg.Broker, port)
+	if scheme == "ws" || scheme == "wss" {
+		path := cfg.Path
+		if path == "" {
+			path = defaultMqttWSPath
+		}
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		brokerURL += path
+	}
+
+	return brokerURL, nil
+}
+
+// return a PEM value given inline or as a file path
+func readPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
+
+// return the TLS config for ssl and wss connections
+func mqttTLSConfig(cfg hemsconfig.Mqtt) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		ca, err := readPEM(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read MQTT CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("MQTT CA bundle contains no valid certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, errors.New("MQTT client certificate and key have to be set both")
+		}
+		certPEM, err := readPEM(cfg.CertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read MQTT client certificate: %w", err)
+		}
+		keyPEM, err := readPEM(cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read MQTT client key: %w", err)
+		}
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MQTT client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// return the client options for the configured broker
+func mqttOptions(cfg hemsconfig.Mqtt, password string) (*mqtt.ClientOptions, error) {
+	brokerURL, err := mqttBrokerURL(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := mqtt.NewClientOptions()
+	opts.AddBroker(brokerURL)
+
+	if strings.HasPrefix(brokerURL, "ssl://") || strings.HasPrefix(brokerURL, "wss://") {
+		tlsConfig, err := mqttTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = fmt.Sprintf("eebus2mqtt-hems-%d", time.Now().UnixNano())
+	}
+	opts.SetClientID(clientID)
+
+	keepAlive := cfg.KeepAlive
+	if keepAlive <= 0 {
+		keepAlive = defaultMqttKeepAlive
+	}
+	opts.SetKeepAlive(time.Duration(keepAlive) * time.Second)
+
+	cleanSession := true
+	if cfg.CleanSession != nil {
+		cleanSession = *cfg.CleanSession
+	}
+	opts.SetCleanSession(cleanSession)
+
+	opts.SetUsername(cfg.Username)
+	opts.SetPassword(password)
+
+	// reconnect in the background, subscriptions are renewed in OnConnect
+	opts.SetAutoReconnect(true)
+	opts.SetConnectRetry(true)
+	opts.SetConnectRetryInterval(10 * time.Second)
+	opts.SetMaxReconnectInterval(time.Minute)
+	opts.SetResumeSubs(true)
+
+	return opts, nil
+}
+
+// return the client options of the configured broker with the decrypted password
+func brokerOptions() (*mqtt.ClientOptions, error) {
+	pw, err := openSecret(func(c *hemsconfig.Config) *string { return &c.Mqtt.Password })
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt mqtt.mqttPassword: %v, set the password again in %s", err, store.Path())
+	}
+
+	cfg := mqttConfig()
+
+	// the QoS is checked by the config validation
+	if cfg.QoS != nil {
+		qos = *cfg.QoS
+	}
+
+	return mqttOptions(cfg, pw)
+}
+
+func mqttConnect(h *hems) {
+	opts, err := brokerOptions()
+	if err != nil {
+		fatal("invalid MQTT settings", "error", err)
+	}
+	opts.SetDefaultPublishHandler(messagePubHandler)
+	opts.SetWill(availabilityTopic, payloadOffline, qos, true)
+	opts.OnConnect = func(client mqtt.Client) {
+		mqttLog().Info("connected")
+		sub(client, h.onCommandMessage)
+		publishDiscovery(client)
+		publishLogLevels(client)
+	}
+	opts.OnConnectionLost = connectLostHandler
+	opts.SetReconnectingHandler(reconnectingHandler)
+
+	client = mqtt.NewClient(opts)
+
+	// with connect retry the token only completes once connected, do not block the EEBUS service
+	token := client.Connect()
+	if !token.WaitTimeout(10 * time.Second) {
+		mqttLog().Warn("broker not reachable yet, retrying in the background")
+	} else if err := token.Error(); err != nil {
+		mqttLog().Error("connection failed", "error", err)
+		logs.Audit().Info("MQTT connection failed", "error", err)
+	}
+}
+
+// connect a client for a command line command
+//
+// The client uses its own client ID, no will and no reconnects, so a running
+// bridge is not affected.
+func mqttDial(timeout time.Duration) (mqtt.Client, error) {
+	opts, err := brokerOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts.SetClientID(fmt.Sprintf("eebus2mqtt-cli-%d", time.Now().UnixNano()))
+	opts.SetCleanSession(true)
+	opts.SetAutoReconnect(false)
+	opts.SetConnectRetry(false)
+	opts.SetConnectTimeout(timeout)
+
+	c := mqtt.NewClient(opts)
+	token := c.Connect()
+	if !token.WaitTimeout(timeout) {
+		return nil, errors.New("MQTT broker not reachable")
+	}
+	if err := token.Error(); err != nil {
+		return nil, fmt.Errorf("MQTT connection failed: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/enbility/eebus-go/devices/hems/certs"
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+)
+
+func (s *HemsSuite) Test_MqttBrokerURL() {
+	tests := []struct {
+		name string
+		cfg  hemsconfig.Mqtt
+		url  string
+		err  string
+	}{
+		{name: "no broker", err: "no MQTT broker configured"},
+		{name: "default scheme", cfg: hemsconfig.Mqtt{Broker: "broker"}, url: "tcp://broker:1883"},
+		{name: "mqtt", cfg: hemsconfig.Mqtt{Broker: "broker", Scheme: "mqtt"}, url: "tcp://broker:1883"},
+		{name: "port", cfg: hemsconfig.Mqtt{Broker: "broker", Port: 1884}, url: "tcp://broker:1884"},
+		{name: "ssl", cfg: hemsconfig.Mqtt{Broker: "broker", Scheme: "ssl"}, url: "ssl://broker:8883"},
+		{name: "mqtts", cfg: hemsconfig.Mqtt{Broker: "broker", Scheme: "MQTTS"}, url: "ssl://broker:8883"},
+		{name: "ws", cfg: hemsconfig.Mqtt{Broker: "broker", Scheme: "ws"}, url: "ws://broker:80/mqtt"},
+		{name: "wss", cfg: hemsconfig.Mqtt{Broker: "broker", Scheme: "wss"}, url: "wss://broker:443/mqtt"},
+		{name: "ws path", cfg: hemsconfig.Mqtt{Broker: "broker", Scheme: "ws", Port: 9001, Path: "ws"}, url: "ws://broker:9001/ws"},
+		{name: "path without ws", cfg: hemsconfig.Mqtt{Broker: "broker", Path: "/ws"}, url: "tcp://broker:1883"},
+		{name: "URL", cfg: hemsconfig.Mqtt{Broker: "wss://broker:8443/mqtt", Scheme: "tcp", Port: 1883}, url: "wss://broker:8443/mqtt"},
+		{name: "invalid URL", cfg: hemsconfig.Mqtt{Broker: "tcp://broker:port"}, err: "invalid MQTT broker URL"},
+		{name: "unsupported scheme", cfg: hemsconfig.Mqtt{Broker: "broker", Scheme: "http"}, err: `unsupported MQTT scheme "http", use tcp, ssl, ws or wss`},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			url, err := mqttBrokerURL(test.cfg)
+			if test.err != "" {
+				s.ErrorContains(err, test.err)
+				return
+			}
+			s.Nil(err)
+			s.Equal(test.url, url)
+		})
+	}
+}
+
+func (s *HemsSuite) Test_MqttTLSConfig() {
+	pair, err := certs.Create("0123456789")
+	s.Require().NoError(err)
+
+	dir := s.T().TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	s.Require().NoError(os.WriteFile(certFile, []byte(pair.Cert), 0600))
+
+	tests := []struct {
+		name  string
+		cfg   hemsconfig.Mqtt
+		certs int
+		ca    bool
+		err   string
+	}{
+		{name: "default"},
+		{name: "CA inline", cfg: hemsconfig.Mqtt{CAFile: pair.Cert}, ca: true},
+		{name: "CA file", cfg: hemsconfig.Mqtt{CAFile: certFile}, ca: true},
+		{name: "CA file missing", cfg: hemsconfig.Mqtt{CAFile: filepath.Join(dir, "missing.pem")}, err: "unable to read MQTT CA bundle"},
+		{name: "bad CA", cfg: hemsconfig.Mqtt{CAFile: "-----BEGIN CERTIFICATE-----\ninvalid\n-----END CERTIFICATE-----"}, err: "MQTT CA bundle contains no valid certificate"},
+		{name: "client certificate", cfg: hemsconfig.Mqtt{CertFile: certFile, KeyFile: pair.Key}, certs: 1},
+		{name: "certificate without key", cfg: hemsconfig.Mqtt{CertFile: certFile}, err: "MQTT client certificate and key have to be set both"},
+		{name: "key without certificate", cfg: hemsconfig.Mqtt{KeyFile: pair.Key}, err: "MQTT client certificate and key have to be set both"},
+		{name: "key of another certificate", cfg: hemsconfig.Mqtt{CertFile: pair.Cert, KeyFile: pair.Cert}, err: "invalid MQTT client certificate"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			tlsConfig, err := mqttTLSConfig(test.cfg)
+			if test.err != "" {
+				s.ErrorContains(err, test.err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Len(tlsConfig.Certificates, test.certs)
+			s.Equal(test.ca, tlsConfig.RootCAs != nil)
+		})
+	}
+}
+
+func (s *HemsSuite) Test_MqttOptions() {
+	cleanSession := false
+	opts, err := mqttOptions(hemsconfig.Mqtt{
+		Broker:       "broker",
+		Scheme:       "wss",
+		Username:     "user",
+		ClientID:     "hems",
+		KeepAlive:    60,
+		CleanSession: &cleanSession,
+	}, "secret")
+	s.Require().NoError(err)
+
+	s.Equal("wss://broker:443/mqtt", opts.Servers[0].String())
+	s.NotNil(opts.TLSConfig)
+	s.Equal("hems", opts.ClientID)
+	s.Equal(int64(60), opts.KeepAlive)
+	s.False(opts.CleanSession)
+	s.Equal("user", opts.Username)
+	s.Equal("secret", opts.Password)
+
+	// the defaults without TLS
+	opts, err = mqttOptions(hemsconfig.Mqtt{Broker: "broker"}, "")
+	s.Require().NoError(err)
+	s.Equal("tcp://broker:1883", opts.Servers[0].String())
+	s.True(strings.HasPrefix(opts.ClientID, "eebus2mqtt-hems-"))
+	s.Equal(int64(defaultMqttKeepAlive), opts.KeepAlive)
+	s.True(opts.CleanSession)
+
+	// the TLS settings are only checked for TLS connections
+	_, err = mqttOptions(hemsconfig.Mqtt{Broker: "broker", Scheme: "ssl", CertFile: "cert.pem"}, "")
+	s.EqualError(err, "MQTT client certificate and key have to be set both")
+	_, err = mqttOptions(hemsconfig.Mqtt{Broker: "broker", CertFile: "cert.pem"}, "")
+	s.Nil(err)
+
+	_, err = mqttOptions(hemsconfig.Mqtt{}, "")
+	s.EqualError(err, "no MQTT broker configured")
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+	shipapi "github.com/enbility/ship-go/api"
+	shiputil "github.com/enbility/ship-go/util"
+)
+
+// MQTT pairing workflow
+//
+// The local SKI, the QR code text and the services visible via mDNS are published
+// below eebus2mqtt/hems/pairing/. Remotes are paired, unpaired and pending pairings
+// cancelled with the commands pairing/pair, pairing/unpair and pairing/cancel. The
+// payload is the SKI, pair also accepts a JSON remote config to set name and SHIP ID.
+const pairingTopic = "pairing"
+
+// a service visible via mDNS
+type visibleService struct {
+	shipapi.RemoteService
+	Paired bool `json:"paired"`
+}
+
+// pairing state update of a remote
+type pairingState struct {
+	Ski   string `json:"ski"`
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+}
+
+// a pairing command applies the remote and returns an error if it failed
+type pairingCommand func(h *hems, remote hemsconfig.Remote) error
+
+var pairingCommands = map[string]pairingCommand{
+	"pair":   pairRemote,
+	"unpair": unpairRemote,
+	"cancel": cancelPairing,
+}
+
+var visibleMux sync.Mutex
+var visibleServices []shipapi.RemoteService
+
+// publish the local SKI and the QR code text
+func (h *hems) publishPairingInfo() {
+	client.Publish(topicPrefix+pairingTopic+"/local_ski", qos, true, h.myService.LocalService().SKI())
+	client.Publish(topicPrefix+pairingTopic+"/qrcode", qos, true, h.myService.QRCodeText())
+}
+
+// publish the services visible via mDNS
+func publishVisibleServices() {
+	visibleMux.Lock()
+	services := make([]visibleService, 0, len(visibleServices))
+	for _, service := range visibleServices {
+		_, paired := findRemote(service.Ski)
+		services = append(services, visibleService{RemoteService: service, Paired: paired})
+	}
+	visibleMux.Unlock()
+
+	payload, err := json.Marshal(map[string][]visibleService{"services": services})
+	if err != nil {
+		return
+	}
+	client.Publish(topicPrefix+pairingTopic+"/visible", qos, true, payload)
+	client.Publish(topicPrefix+pairingTopic+"/visible_count", qos, true, fmt.Sprintf("%d", len(services)))
+}
+
+// publish a pairing state update
+func publishPairingState(ski string, detail *shipapi.ConnectionStateDetail) {
+	state := pairingState{
+		Ski:   ski,
+		State: pairingStateName(detail.State()),
+	}
+	if err := detail.Error(); err != nil {
+		state.Error = err.Error()
+	}
+
+	payload, _ := json.Marshal(state)
+	client.Publish(topicPrefix+pairingTopic+"/state", qos, false, payload)
+}
+
+// handle pairing commands on eebus2mqtt/hems/pairing/<command>/set
+func (h *hems) onPairingMessage(client mqtt.Client, command string, payload []byte) {
+	name := pairingTopic + "/" + command
+	ack := commandAck{Command: name}
+
+	if err := h.applyPairingCommand(command, payload); err != nil {
+		ack.Error = err.Error()
+		auditEvent("MQTT command rejected", "command", name, "error", err)
+	} else {
+		ack.Success = true
+		auditEvent("MQTT command applied", "command", name, "value", strings.TrimSpace(string(payload)))
+		publishVisibleServices()
+	}
+
+	ackPayload, _ := json.Marshal(ack)
+	client.Publish(topicPrefix+name+ackTopicSuffix, qos, false, ackPayload)
+}
+
+// parse the payload and run the pairing command
+func (h *hems) applyPairingCommand(command string, payload []byte) error {
+	cmd, ok := pairingCommands[command]
+	if !ok {
+		return fmt.Errorf("unknown command %q", pairingTopic+"/"+command)
+	}
+
+	var remote hemsconfig.Remote
+	text := strings.TrimSpace(string(payload))
+	if strings.HasPrefix(text, "{") {
+		if err := json.Unmarshal(payload, &remote); err != nil {
+			return fmt.Errorf("invalid payload: %w", err)
+		}
+	} else {
+		remote.SKI = text
+	}
+
+	remote.SKI = shiputil.NormalizeSKI(remote.SKI)
+	if remote.SKI == "" {
+		return errors.New("a SKI is required")
+	}
+	if err := remote.Validate(); err != nil {
+		return err
+	}
+
+	if h.myService == nil {
+		return errNotReady
+	}
+
+	return cmd(h, remote)
+}
+
+// add a remote to the config and start pairing
+func pairRemote(h *hems, remote hemsconfig.Remote) error {
+	if remote.SKI == shiputil.NormalizeSKI(h.myService.LocalService().SKI()) {
+		return errors.New("the local SKI can not be paired")
+	}
+
+	// the SHIP ID of a visible service allows connecting without another mDNS lookup
+	if remote.ShipID == "" {
+		visibleMux.Lock()
+		for _, service := range visibleServices {
+			if shiputil.NormalizeSKI(service.Ski) == remote.SKI {
+				remote.ShipID = service.Identifier
+			}
+		}
+		visibleMux.Unlock()
+	}
+
+	configMux.Lock()
+	remote, err := addRemoteConfig(remote)
+	if err == nil {
+		saveConfigLocked()
+	}
+	configMux.Unlock()
+	if err != nil {
+		return err
+	}
+
+	h.myService.RegisterRemoteSKI(remote.SKI, remote.ShipID)
+	publishRemote(remote.SKI, "connected", "false")
+	publishDiscoveryEntities(client, remoteDiscoveryEntities())
+
+	return nil
+}
+
+// remove a remote from the config and disconnect it
+func unpairRemote(h *hems, remote hemsconfig.Remote) error {
+	if _, ok := findRemote(remote.SKI); !ok {
+		return errors.New("remote is not paired")
+	}
+
+	// clear the retained topics and entities while the remote is still known
+	clearDiscoveryEntities(client, remoteDiscoveryEntities())
+	for _, name := range []string{"connected", "pairing_state", "pair_again", "ship_id"} {
+		publishRemote(remote.SKI, name, "")
+	}
+
+	updateConfig(func() {
+		remotes := make([]hemsconfig.Remote, 0, len(config.Hems.Remotes))
+		for _, item := range config.Hems.Remotes {
+			if shiputil.NormalizeSKI(item.SKI) != remote.SKI {
+				remotes = append(remotes, item)
+			}
+		}
+		config.Hems.Remotes = remotes
+	})
+
+	h.myService.UnregisterRemoteSKI(remote.SKI)
+	publishDiscoveryEntities(client, remoteDiscoveryEntities())
+
+	return nil
+}
+
+// cancel a pending pairing
+func cancelPairing(h *hems, remote hemsconfig.Remote) error {
+	h.myService.CancelPairingWithSKI(remote.SKI)
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+func (s *HemsSuite) Test_ApplyPairingCommand() {
+	tests := []struct {
+		name    string
+		command string
+		payload string
+		err     string
+	}{
+		{name: "unknown command", command: "connect", payload: testSKI, err: `unknown command "pairing/connect"`},
+		{name: "invalid JSON", command: "pair", payload: `{"ski":`, err: "invalid payload: unexpected end of JSON input"},
+		{name: "no SKI", command: "pair", payload: " ", err: "a SKI is required"},
+		{name: "no SKI in JSON", command: "pair", payload: `{"name":"wallbox"}`, err: "a SKI is required"},
+		{name: "invalid SKI", command: "unpair", payload: "0123", err: `remote.ski: "0123" is not a valid SKI, 40 hex characters are required`},
+		{name: "invalid name", command: "pair", payload: `{"ski":"` + testSKI + `","name":"wall box"}`, err: `remote.name: "wall box" must not contain /, +, # or spaces`},
+		// the SKI is valid, the service is not running in the tests
+		{name: "SKI", command: "cancel", payload: testSKI, err: errNotReady.Error()},
+		{name: "formatted SKI", command: "unpair", payload: "0123 4567 89AB CDEF 0123 4567 89AB CDEF 0123 4567", err: errNotReady.Error()},
+		{name: "JSON remote", command: "pair", payload: `{"ski":"` + testSKI + `","name":"wallbox"}`, err: errNotReady.Error()},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := s.sut.applyPairingCommand(test.command, []byte(test.payload))
+			s.EqualError(err, test.err)
+		})
+	}
+}
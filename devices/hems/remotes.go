@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+	shipapi "github.com/enbility/ship-go/api"
+	shiputil "github.com/enbility/ship-go/util"
+)
+
+// Remote EEBUS services the bridge is paired with
+//
+// Every remote has its own MQTT namespace eebus2mqtt/hems/remote/<name>/ with
+// the topics connected, pairing_state, pair_again and ship_id. The name defaults to the SKI.
+const remoteTopic = "remote"
+
+// connection state of the remotes by SKI, guarded by configMux
+var remotesConnected = map[string]bool{}
+
+// return a copy of the configured remotes
+func remoteConfigs() []hemsconfig.Remote {
+	configMux.Lock()
+	defer configMux.Unlock()
+
+	return append([]hemsconfig.Remote(nil), config.Hems.Remotes...)
+}
+
+// return the configured remote for a SKI
+func findRemote(ski string) (hemsconfig.Remote, bool) {
+	ski = shiputil.NormalizeSKI(ski)
+
+	for _, remote := range remoteConfigs() {
+		if shiputil.NormalizeSKI(remote.SKI) == ski {
+			return remote, true
+		}
+	}
+	return hemsconfig.Remote{}, false
+}
+
+// return the MQTT namespace of a remote
+func remoteName(ski string) string {
+	// the validation rejects wildcards and separators in the name
+	if remote, ok := findRemote(ski); ok && remote.Name != "" {
+		return remote.Name
+	}
+	return shiputil.NormalizeSKI(ski)
+}
+
+// store the SHIP ID of a remote and save the config if it changed
+func updateRemoteShipID(ski, shipID string) {
+	configMux.Lock()
+	defer configMux.Unlock()
+
+	ski = shiputil.NormalizeSKI(ski)
+	for i, remote := range config.Hems.Remotes {
+		if shiputil.NormalizeSKI(remote.SKI) == ski && remote.ShipID != shipID {
+			config.Hems.Remotes[i].ShipID = shipID
+			saveConfigLocked()
+			return
+		}
+	}
+}
+
+// store the local SKI a remote is paired with and save the config if it changed
+func updateRemoteLocalSKI(ski, localSKI string) {
+	configMux.Lock()
+	defer configMux.Unlock()
+
+	ski = shiputil.NormalizeSKI(ski)
+	localSKI = shiputil.NormalizeSKI(localSKI)
+	for i, remote := range config.Hems.Remotes {
+		if shiputil.NormalizeSKI(remote.SKI) == ski && remote.LocalSKI != localSKI {
+			config.Hems.Remotes[i].LocalSKI = localSKI
+			saveConfigLocked()
+			return
+		}
+	}
+}
+
+// return the remotes paired with another local SKI
+func remotesToPairAgain(localSKI string) []hemsconfig.Remote {
+	localSKI = shiputil.NormalizeSKI(localSKI)
+
+	var remotes []hemsconfig.Remote
+	for _, remote := range remoteConfigs() {
+		if remote.LocalSKI != "" && shiputil.NormalizeSKI(remote.LocalSKI) != localSKI {
+			remotes = append(remotes, remote)
+		}
+	}
+	return remotes
+}
+
+// add a remote to the config or update the name and SHIP ID of a paired one
+//
+// returns the remote as stored in the config or an error if its MQTT
+// namespace is used by another remote, configMux must be held
+func addRemoteConfig(remote hemsconfig.Remote) (hemsconfig.Remote, error) {
+	index := -1
+	for i, item := range config.Hems.Remotes {
+		ski := shiputil.NormalizeSKI(item.SKI)
+		switch {
+		case ski == remote.SKI:
+			index = i
+		case remote.Name != "" && (remote.Name == item.Name || remote.Name == ski):
+			return remote, fmt.Errorf("name %q is used by remote %s", remote.Name, ski)
+		case item.Name == remote.SKI:
+			return remote, fmt.Errorf("the SKI is the name of remote %s", ski)
+		}
+	}
+
+	if index < 0 {
+		config.Hems.Remotes = append(config.Hems.Remotes, remote)
+		return remote, nil
+	}
+
+	if remote.Name != "" {
+		config.Hems.Remotes[index].Name = remote.Name
+	}
+	if remote.ShipID != "" {
+		config.Hems.Remotes[index].ShipID = remote.ShipID
+	}
+	return config.Hems.Remotes[index], nil
+}
+
+// store and publish the connection state of a remote
+func setRemoteConnected(ski string, connected bool) {
+	configMux.Lock()
+	remotesConnected[shiputil.NormalizeSKI(ski)] = connected
+	configMux.Unlock()
+
+	publishRemote(ski, "connected", fmt.Sprintf("%t", connected))
+}
+
+// return true if a remote is connected
+func remoteConnected(ski string) bool {
+	configMux.Lock()
+	defer configMux.Unlock()
+
+	return remotesConnected[shiputil.NormalizeSKI(ski)]
+}
+
+// publish a value below the namespace of a remote
+func publishRemote(ski, name, value string) {
+	client.Publish(fmt.Sprintf("%s%s/%s/%s", topicPrefix, remoteTopic, remoteName(ski), name), qos, true, value)
+}
+
+// return the Home Assistant entities of all configured remotes
+func remoteDiscoveryEntities() []discoveryEntity {
+	var entities []discoveryEntity
+
+	for _, remote := range remoteConfigs() {
+		name := remoteName(remote.SKI)
+		path := remoteTopic + "/" + name
+		id := remoteTopic + "_" + name
+
+		connected := binarySensor(id+"_connected", "Remote "+name+" connected", path+"/connected")
+		connected.deviceClass = "connectivity"
+		pairing := textSensor(id+"_pairing_state", "Remote "+name+" pairing state", path+"/pairing_state")
+		pairing.category = "diagnostic"
+
+		entities = append(entities, connected, pairing)
+	}
+
+	return entities
+}
+
+// return a readable name of a SHIP connection state
+func pairingStateName(state shipapi.ConnectionState) string {
+	switch state {
+	case shipapi.ConnectionStateNone:
+		return "none"
+	case shipapi.ConnectionStateQueued:
+		return "queued"
+	case shipapi.ConnectionStateInitiated:
+		return "initiated"
+	case shipapi.ConnectionStateReceivedPairingRequest:
+		return "received_pairing_request"
+	case shipapi.ConnectionStateInProgress:
+		return "in_progress"
+	case shipapi.ConnectionStateTrusted:
+		return "trusted"
+	case shipapi.ConnectionStatePin:
+		return "pin"
+	case shipapi.ConnectionStateCompleted:
+		return "completed"
+	case shipapi.ConnectionStateRemoteDeniedTrust:
+		return "remote_denied_trust"
+	case shipapi.ConnectionStateError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
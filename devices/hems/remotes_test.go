@@ -0,0 +1,57 @@
+package main
+
+import (
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+)
+
+const otherSKI = "fedcba9876543210fedcba9876543210fedcba98"
+
+func (s *HemsSuite) Test_RemoteName() {
+	config.Hems.Remotes = []hemsconfig.Remote{{SKI: testSKI, Name: "wallbox"}, {SKI: otherSKI}}
+
+	s.Equal("wallbox", remoteName(testSKI))
+	s.Equal("wallbox", remoteName("0123 4567 89AB CDEF 0123 4567 89AB CDEF 0123 4567"))
+	s.Equal(otherSKI, remoteName(otherSKI))
+	s.Equal("00112233445566778899aabbccddeeff00112233", remoteName("00112233445566778899AABBCCDDEEFF00112233"))
+}
+
+func (s *HemsSuite) Test_AddRemoteConfig() {
+	configMux.Lock()
+	defer configMux.Unlock()
+
+	remote, err := addRemoteConfig(hemsconfig.Remote{SKI: testSKI, Name: "wallbox"})
+	s.Nil(err)
+	s.Equal(hemsconfig.Remote{SKI: testSKI, Name: "wallbox"}, remote)
+
+	// a paired remote keeps the values that are not given
+	remote, err = addRemoteConfig(hemsconfig.Remote{SKI: testSKI, ShipID: "ship"})
+	s.Nil(err)
+	s.Equal(hemsconfig.Remote{SKI: testSKI, Name: "wallbox", ShipID: "ship"}, remote)
+
+	remote, err = addRemoteConfig(hemsconfig.Remote{SKI: testSKI, Name: "steuerbox"})
+	s.Nil(err)
+	s.Equal("steuerbox", remote.Name)
+	s.Len(config.Hems.Remotes, 1)
+
+	// the MQTT namespace of another remote is not reused
+	_, err = addRemoteConfig(hemsconfig.Remote{SKI: otherSKI, Name: "steuerbox"})
+	s.EqualError(err, `name "steuerbox" is used by remote `+testSKI)
+	_, err = addRemoteConfig(hemsconfig.Remote{SKI: otherSKI, Name: testSKI})
+	s.EqualError(err, `name "`+testSKI+`" is used by remote `+testSKI)
+	s.Len(config.Hems.Remotes, 1)
+
+	remote, err = addRemoteConfig(hemsconfig.Remote{SKI: otherSKI})
+	s.Nil(err)
+	s.Equal(hemsconfig.Remote{SKI: otherSKI}, remote)
+	s.Equal([]hemsconfig.Remote{{SKI: testSKI, Name: "steuerbox", ShipID: "ship"}, {SKI: otherSKI}}, config.Hems.Remotes)
+}
+
+func (s *HemsSuite) Test_AddRemoteConfig_SKIIsName() {
+	config.Hems.Remotes = []hemsconfig.Remote{{SKI: testSKI, Name: otherSKI}}
+
+	configMux.Lock()
+	defer configMux.Unlock()
+
+	_, err := addRemoteConfig(hemsconfig.Remote{SKI: otherSKI})
+	s.EqualError(err, "the SKI is the name of remote "+testSKI)
+}
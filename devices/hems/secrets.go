@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+	"github.com/enbility/eebus-go/devices/hems/secrets"
+)
+
+// encrypts the secret values of the config
+var secretBox *secrets.Box
+
+// create the box for the secret values with the configured key source
+func setupSecrets() error {
+	if config.Secrets.Salt == "" {
+		salt, err := secrets.NewSalt()
+		if err != nil {
+			return err
+		}
+		updateConfig(func() { config.Secrets.Salt = salt })
+	}
+
+	// the key file is kept in the data directory unless configured otherwise
+	keyFile := config.Secrets.KeyFile
+	if keyFile == "" {
+		keyFile = filepath.Join(store.DataDir(), secrets.DefaultKeyFile)
+	}
+
+	source, err := secrets.Source(secrets.Options{
+		Backend:    config.Secrets.Backend,
+		KeyFile:    keyFile,
+		Env:        config.Secrets.Env,
+		SecretFile: config.Secrets.SecretFile,
+	})
+	if err != nil {
+		return err
+	}
+
+	secretBox, err = secrets.New(source, config.Secrets.Salt)
+	if err != nil {
+		return fmt.Errorf("unable to set up the secret store: %w", err)
+	}
+	bridgeLog().Info("secrets are encrypted", "source", source.String())
+
+	return nil
+}
+
+// return the plaintext of a secret config value
+//
+// field returns the value in the config. Plaintext values and values
+// encrypted with the serial number by older releases are encrypted with the
+// secret box and saved. A value that looks encrypted by an older release but
+// can not be decrypted is an error, it is not taken as the plaintext.
+func openSecret(field func(c *hemsconfig.Config) *string) (string, error) {
+	configMux.Lock()
+	value, sn := *field(&config), config.Hems.SN
+	configMux.Unlock()
+
+	if value == "" {
+		return "", nil
+	}
+	if secrets.IsSealed(value) {
+		return secretBox.Open(value)
+	}
+
+	plain := value
+	if secrets.IsLegacy(value) {
+		legacy, err := secrets.DecryptLegacy(sn, value)
+		if err != nil {
+			return "", fmt.Errorf("value of an older release can not be decrypted: %w", err)
+		}
+		plain = legacy
+	}
+
+	sealed, err := secretBox.Seal(plain)
+	if err != nil {
+		return "", err
+	}
+	updateConfig(func() { *field(&config) = sealed })
+
+	return plain, nil
+}
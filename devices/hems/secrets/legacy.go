@@ -0,0 +1,70 @@
+package secrets
+
+import (
+	"encoding/base64"
+	"errors"
+	"regexp"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// the key of older releases was derived from the serial number
+var legacyPepper = []byte("ebus2mqtt")
+
+var legacySerialPattern = regexp.MustCompile(`^\d{10}$`)
+
+// return the Box of older releases
+func legacyBox(serial string) (*Box, error) {
+	if !legacySerialPattern.MatchString(serial) {
+		return nil, errors.New("serial_number must be exactly 10 digits")
+	}
+
+	salt := make([]byte, 0, len(serial)+len(legacyPepper))
+	salt = append(salt, serial...)
+	salt = append(salt, legacyPepper...)
+
+	key := argon2.IDKey([]byte(serial), salt, argonTime, argonMemory, argonThreads, keyLen)
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Box{aead: aead}, nil
+}
+
+// size of the GCM nonce and tag of the values of older releases
+const legacyOverhead = 12 + 16
+
+// IsLegacy returns true if the value looks encrypted by older releases
+//
+// The values are the base64 encoded nonce, ciphertext and tag. A plaintext
+// value of this form can not be told apart and has to be set again.
+func IsLegacy(value string) bool {
+	data, err := base64.StdEncoding.DecodeString(value)
+	return err == nil && len(data) >= legacyOverhead
+}
+
+// DecryptLegacy decrypts a value encrypted with the serial number by older releases
+//
+// Used to migrate existing configs, the values are encrypted again with a Box.
+func DecryptLegacy(serial, value string) (string, error) {
+	box, err := legacyBox(serial)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+
+	nonceSize := box.aead.NonceSize()
+	if len(data) < nonceSize {
+		return "", errors.New("ciphertext too short")
+	}
+
+	plaintext, err := box.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,32 @@
+//go:build linux
+
+package secrets
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// read a passphrase from a terminal without echo
+func readPassphrase(input *os.File) ([]byte, error) {
+	fd := int(input.Fd())
+
+	termios, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		return nil, errors.New("no terminal available for the passphrase prompt")
+	}
+
+	noEcho := *termios
+	noEcho.Lflag &^= unix.ECHO
+	noEcho.Lflag |= unix.ICANON | unix.ISIG
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, &noEcho); err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = unix.IoctlSetTermios(fd, unix.TCSETS, termios)
+	}()
+
+	return readLine(input)
+}
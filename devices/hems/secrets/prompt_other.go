@@ -0,0 +1,12 @@
+//go:build !linux
+
+package secrets
+
+import (
+	"os"
+)
+
+// read a passphrase, the input is echoed on this platform
+func readPassphrase(input *os.File) ([]byte, error) {
+	return readLine(input)
+}
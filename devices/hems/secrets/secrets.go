@@ -0,0 +1,129 @@
+// Package secrets encrypts the secret values of the HEMS config
+//
+// The key is derived from a key source outside the config: a key file, an
+// environment variable, a Docker or Kubernetes secret file or a passphrase
+// prompt. Encrypted values are stored as text with the Prefix, values without
+// the prefix are plaintext.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Prefix marks values encrypted by a Box
+const Prefix = "enc:v1:"
+
+// Argon2id parameters of the key derivation
+const (
+	argonTime    uint32 = 3
+	argonMemory  uint32 = 64 * 1024 // KiB
+	argonThreads uint8  = 4
+	keyLen              = 32 // AES-256
+	saltLen             = 16
+)
+
+// KeySource provides the key material the encryption key is derived from
+type KeySource interface {
+	// Key returns the key material, a random key or a passphrase
+	Key() ([]byte, error)
+	// String describes the source for messages
+	String() string
+}
+
+// Box encrypts and decrypts config values
+type Box struct {
+	aead cipher.AEAD
+}
+
+// NewSalt returns a random salt for the key derivation, base64 encoded
+func NewSalt() (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(salt), nil
+}
+
+// New returns a Box with the key derived from the source and the base64 encoded salt
+func New(source KeySource, salt string) (*Box, error) {
+	saltBytes, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil || len(saltBytes) < 8 {
+		return nil, errors.New("invalid salt, at least 8 base64 encoded bytes are required")
+	}
+
+	material, err := source.Key()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	if len(material) == 0 {
+		return nil, fmt.Errorf("%s: the key is empty", source)
+	}
+
+	key := argon2.IDKey(material, saltBytes, argonTime, argonMemory, argonThreads, keyLen)
+	clear(material)
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Box{aead: aead}, nil
+}
+
+// return an AES-GCM AEAD and clear the key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	clear(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// IsSealed returns true if the value was encrypted by a Box
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Seal encrypts a value
+func (b *Box) Seal(plaintext string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	out := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return Prefix + base64.StdEncoding.EncodeToString(out), nil
+}
+
+// Open decrypts a sealed value, other values are returned unchanged
+func (b *Box) Open(value string) (string, error) {
+	if !IsSealed(value) {
+		return value, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, Prefix))
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted value: %w", err)
+	}
+
+	nonceSize := b.aead.NonceSize()
+	if len(data) < nonceSize {
+		return "", errors.New("invalid encrypted value: ciphertext too short")
+	}
+
+	plaintext, err := b.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return "", errors.New("unable to decrypt, the value was encrypted with another key")
+	}
+
+	return string(plaintext), nil
+}
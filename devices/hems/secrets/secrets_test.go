@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"os"
+)
+
+func (s *SecretsSuite) Test_SealOpen() {
+	s.T().Setenv("TEST_SECRET_KEY", "passphrase")
+
+	box, err := New(Env("TEST_SECRET_KEY"), testSalt)
+	s.Require().NoError(err)
+
+	sealed, err := box.Seal("password")
+	s.Nil(err)
+	s.True(IsSealed(sealed))
+	s.NotContains(sealed, "password")
+
+	plain, err := box.Open(sealed)
+	s.Nil(err)
+	s.Equal("password", plain)
+
+	// plaintext values are returned unchanged
+	plain, err = box.Open("password")
+	s.Nil(err)
+	s.Equal("password", plain)
+
+	// another key can not decrypt the value
+	os.Setenv("TEST_SECRET_KEY", "other")
+	other, err := New(Env("TEST_SECRET_KEY"), testSalt)
+	s.Require().NoError(err)
+	_, err = other.Open(sealed)
+	s.ErrorContains(err, "another key")
+
+	_, err = box.Open(Prefix + "!")
+	s.ErrorContains(err, "invalid encrypted value")
+}
+
+func (s *SecretsSuite) Test_New_Errors() {
+	_, err := New(Env("TEST_SECRET_UNSET"), testSalt)
+	s.ErrorContains(err, "environment variable TEST_SECRET_UNSET: not set")
+
+	s.T().Setenv("TEST_SECRET_KEY", "passphrase")
+	_, err = New(Env("TEST_SECRET_KEY"), "")
+	s.ErrorContains(err, "invalid salt")
+}
+
+func (s *SecretsSuite) Test_NewSalt() {
+	salt, err := NewSalt()
+	s.Nil(err)
+
+	other, err := NewSalt()
+	s.Nil(err)
+	s.NotEqual(salt, other)
+}
+
+func (s *SecretsSuite) Test_DecryptLegacy() {
+	value, err := legacySeal("0123456789", "4200")
+	s.Require().NoError(err)
+
+	plain, err := DecryptLegacy("0123456789", value)
+	s.Nil(err)
+	s.Equal("4200", plain)
+
+	_, err = DecryptLegacy("9876543210", value)
+	s.NotNil(err)
+
+	_, err = DecryptLegacy("123", value)
+	s.ErrorContains(err, "10 digits")
+}
+
+func (s *SecretsSuite) Test_IsLegacy() {
+	value, err := legacySeal("0123456789", "")
+	s.Require().NoError(err)
+	s.True(IsLegacy(value))
+
+	s.False(IsLegacy("password"))
+	s.False(IsLegacy("cGFzc3dvcmQ="))
+	s.False(IsLegacy(""))
+}
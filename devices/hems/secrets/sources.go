@@ -0,0 +1,200 @@
+package secrets
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// key source backends
+const (
+	BackendKeyFile    = "keyfile"
+	BackendEnv        = "env"
+	BackendSecretFile = "secretfile"
+	BackendPrompt     = "prompt"
+)
+
+// defaults of the key sources
+const (
+	DefaultKeyFile    = "secret.key"
+	DefaultEnv        = "EEBUS2MQTT_SECRET_KEY"
+	DefaultSecretFile = "/run/secrets/eebus2mqtt_key"
+)
+
+// Options selects and configures the key source
+type Options struct {
+	Backend    string // one of the backends, empty selects env or secret file if present, key file otherwise
+	KeyFile    string // default DefaultKeyFile
+	Env        string // default DefaultEnv
+	SecretFile string // default DefaultSecretFile
+
+	// passphrase prompt, default os.Stdin and os.Stderr
+	Input  *os.File
+	Output io.Writer
+}
+
+// Source returns the key source selected by the options
+func Source(o Options) (KeySource, error) {
+	if o.KeyFile == "" {
+		o.KeyFile = DefaultKeyFile
+	}
+	if o.Env == "" {
+		o.Env = DefaultEnv
+	}
+	if o.SecretFile == "" {
+		o.SecretFile = DefaultSecretFile
+	}
+
+	switch o.Backend {
+	case "":
+		if os.Getenv(o.Env) != "" {
+			return Env(o.Env), nil
+		}
+		if _, err := os.Stat(o.SecretFile); err == nil {
+			return SecretFile(o.SecretFile), nil
+		}
+		return KeyFile(o.KeyFile), nil
+	case BackendKeyFile:
+		return KeyFile(o.KeyFile), nil
+	case BackendEnv:
+		return Env(o.Env), nil
+	case BackendSecretFile:
+		return SecretFile(o.SecretFile), nil
+	case BackendPrompt:
+		return Prompt(o.Input, o.Output), nil
+	default:
+		return nil, fmt.Errorf("unknown secret backend %q, use %s, %s, %s or %s",
+			o.Backend, BackendKeyFile, BackendEnv, BackendSecretFile, BackendPrompt)
+	}
+}
+
+// KeyFile is a key file, a random key is written on first use
+type KeyFile string
+
+func (f KeyFile) String() string {
+	return "key file " + string(f)
+}
+
+func (f KeyFile) Key() ([]byte, error) {
+	data, err := os.ReadFile(string(f))
+	if errors.Is(err, os.ErrNotExist) {
+		return f.create()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(data), nil
+}
+
+// write a new random key, readable by the owner only
+func (f KeyFile) create() ([]byte, error) {
+	key := make([]byte, keyLen)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	encoded := []byte(base64.StdEncoding.EncodeToString(key))
+
+	if err := os.MkdirAll(filepath.Dir(string(f)), 0700); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(string(f), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Write(append(encoded, '\n')); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	return encoded, nil
+}
+
+// Env is an environment variable containing the key or a passphrase
+type Env string
+
+func (e Env) String() string {
+	return "environment variable " + string(e)
+}
+
+func (e Env) Key() ([]byte, error) {
+	value := os.Getenv(string(e))
+	if value == "" {
+		return nil, errors.New("not set")
+	}
+	return []byte(value), nil
+}
+
+// SecretFile is a read-only Docker or Kubernetes secret containing the key or a passphrase
+type SecretFile string
+
+func (f SecretFile) String() string {
+	return "secret file " + string(f)
+}
+
+func (f SecretFile) Key() ([]byte, error) {
+	data, err := os.ReadFile(string(f))
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(data), nil
+}
+
+// passphrase read from a terminal
+type prompt struct {
+	input  *os.File
+	output io.Writer
+}
+
+// Prompt asks for a passphrase on the terminal, nil uses os.Stdin and os.Stderr
+func Prompt(input *os.File, output io.Writer) KeySource {
+	if input == nil {
+		input = os.Stdin
+	}
+	if output == nil {
+		output = os.Stderr
+	}
+	return &prompt{input: input, output: output}
+}
+
+func (p *prompt) String() string {
+	return "passphrase prompt"
+}
+
+func (p *prompt) Key() ([]byte, error) {
+	fmt.Fprint(p.output, "Passphrase for the eebus2mqtt secrets: ")
+	passphrase, err := readPassphrase(p.input)
+	fmt.Fprintln(p.output)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(passphrase), nil
+}
+
+// read a line from the input
+func readLine(input io.Reader) ([]byte, error) {
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := input.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				return line, nil
+			}
+			line = append(line, buf[0])
+		}
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			return line, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
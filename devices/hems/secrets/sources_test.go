@@ -0,0 +1,89 @@
+package secrets
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func (s *SecretsSuite) Test_KeyFile() {
+	path := filepath.Join(s.dir, "keys", "secret.key")
+
+	key, err := KeyFile(path).Key()
+	s.Nil(err)
+	s.Len(key, 44)
+
+	info, err := os.Stat(path)
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	again, err := KeyFile(path).Key()
+	s.Nil(err)
+	s.Equal(key, again)
+}
+
+func (s *SecretsSuite) Test_SecretFile() {
+	path := filepath.Join(s.dir, "eebus2mqtt_key")
+
+	_, err := SecretFile(path).Key()
+	s.NotNil(err)
+
+	s.Require().NoError(os.WriteFile(path, []byte("passphrase\n"), 0400))
+	key, err := SecretFile(path).Key()
+	s.Nil(err)
+	s.Equal([]byte("passphrase"), key)
+}
+
+func (s *SecretsSuite) Test_Prompt() {
+	input, err := os.Open(os.DevNull)
+	s.Require().NoError(err)
+	defer input.Close()
+
+	_, err = Prompt(input, io.Discard).Key()
+	s.NotNil(err)
+}
+
+func (s *SecretsSuite) Test_Source() {
+	keyFile := filepath.Join(s.dir, "secret.key")
+	secretFile := filepath.Join(s.dir, "secret")
+	options := Options{KeyFile: keyFile, Env: "TEST_SECRET_KEY", SecretFile: secretFile}
+
+	source, err := Source(options)
+	s.Nil(err)
+	s.Equal(KeyFile(keyFile), source)
+
+	s.Require().NoError(os.WriteFile(secretFile, []byte("passphrase"), 0400))
+	source, err = Source(options)
+	s.Nil(err)
+	s.Equal(SecretFile(secretFile), source)
+
+	s.T().Setenv("TEST_SECRET_KEY", "passphrase")
+	source, err = Source(options)
+	s.Nil(err)
+	s.Equal(Env("TEST_SECRET_KEY"), source)
+
+	options.Backend = BackendKeyFile
+	source, err = Source(options)
+	s.Nil(err)
+	s.Equal(KeyFile(keyFile), source)
+
+	options.Backend = BackendPrompt
+	source, err = Source(options)
+	s.Nil(err)
+	s.Equal("passphrase prompt", source.String())
+
+	options.Backend = "vault"
+	_, err = Source(options)
+	s.ErrorContains(err, "unknown secret backend")
+}
+
+func (s *SecretsSuite) Test_ReadLine() {
+	line, err := readLine(bytes.NewReader([]byte("secret\nrest")))
+	s.Nil(err)
+	s.Equal([]byte("secret"), line)
+
+	line, err = readLine(bytes.NewReader([]byte("secret")))
+	s.Nil(err)
+	s.Equal([]byte("secret"), line)
+}
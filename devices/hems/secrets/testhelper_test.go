@@ -0,0 +1,34 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const testSalt = "c2FsdHNhbHRzYWx0c2FsdA=="
+
+func TestSecretsSuite(t *testing.T) {
+	suite.Run(t, new(SecretsSuite))
+}
+
+type SecretsSuite struct {
+	suite.Suite
+
+	dir string
+}
+
+func (s *SecretsSuite) BeforeTest(suiteName, testName string) {
+	s.dir = s.T().TempDir()
+}
+
+// encrypt a value like older releases did
+func legacySeal(serial, plaintext string) (string, error) {
+	box, err := legacyBox(serial)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := box.Seal(plaintext)
+	return strings.TrimPrefix(sealed, Prefix), err
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+	"github.com/enbility/eebus-go/devices/hems/secrets"
+)
+
+func (s *HemsSuite) Test_OpenSecret() {
+	source, err := secrets.Source(secrets.Options{Backend: "keyfile", KeyFile: filepath.Join(s.T().TempDir(), "secret.key")})
+	s.Require().NoError(err)
+	salt, err := secrets.NewSalt()
+	s.Require().NoError(err)
+	secretBox, err = secrets.New(source, salt)
+	s.Require().NoError(err)
+
+	password := func(c *hemsconfig.Config) *string { return &c.Mqtt.Password }
+
+	// a plaintext value is sealed and saved
+	config.Mqtt.Password = "secret"
+	plain, err := openSecret(password)
+	s.Nil(err)
+	s.Equal("secret", plain)
+	s.True(secrets.IsSealed(config.Mqtt.Password))
+
+	plain, err = openSecret(password)
+	s.Nil(err)
+	s.Equal("secret", plain)
+
+	config.Mqtt.Password = ""
+	plain, err = openSecret(password)
+	s.Nil(err)
+	s.Equal("", plain)
+
+	// a value of an older release is not taken as the plaintext
+	config.Hems.SN = "0123456789"
+	legacy := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("x", 32)))
+	config.Mqtt.Password = legacy
+	_, err = openSecret(password)
+	s.ErrorContains(err, "value of an older release can not be decrypted")
+	s.Equal(legacy, config.Mqtt.Password)
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/enbility/eebus-go/usecases/cs/limitstate"
+	shiputil "github.com/enbility/ship-go/util"
+)
+
+// Bridge status
+//
+// A running bridge answers eebus2mqtt/hems/bridge/status/set with a status
+// report on eebus2mqtt/hems/bridge/status/ack. The status command uses it.
+const statusCommand = "bridge/status"
+
+// status report of a running bridge
+type statusReport struct {
+	commandAck
+	Started  time.Time      `json:"started"`
+	LocalSKI string         `json:"local_ski,omitempty"`
+	Port     int            `json:"port,omitempty"`
+	LPP      *limitStatus   `json:"lpp,omitempty"`
+	LPC      *limitStatus   `json:"lpc,omitempty"`
+	Remotes  []remoteStatus `json:"remotes"`
+}
+
+// state of a limit use case
+type limitStatus struct {
+	State        string   `json:"state"`
+	Limit        *float64 `json:"limit,omitempty"` // W, the active limit
+	HeartbeatAge float64  `json:"heartbeat_age"`   // s
+}
+
+// state of a remote
+type remoteStatus struct {
+	SKI          string `json:"ski"`
+	Name         string `json:"name"`
+	Connected    bool   `json:"connected"`
+	PairingState string `json:"pairing_state"`
+}
+
+// return the state of a limit use case
+func newLimitStatus(sm *limitstate.StateMachine) *limitStatus {
+	if sm == nil {
+		return nil
+	}
+
+	status := &limitStatus{
+		State:        sm.State().String(),
+		HeartbeatAge: sm.HeartbeatAge().Seconds(),
+	}
+	if limit, err := sm.ActivePowerLimit(); err == nil && limitActive(sm.State()) {
+		status.Limit = &limit
+	}
+	return status
+}
+
+// return the status report of the bridge
+func (h *hems) statusReport() statusReport {
+	report := statusReport{
+		commandAck: commandAck{Command: statusCommand, Success: true},
+		Started:    started,
+		LPP:        newLimitStatus(h.lppState),
+		LPC:        newLimitStatus(h.lpcState),
+		Remotes:    []remoteStatus{},
+	}
+
+	if h.myService == nil {
+		report.Success = false
+		report.Error = errNotReady.Error()
+		return report
+	}
+	report.LocalSKI = h.myService.LocalService().SKI()
+	report.Port = h.myService.Configuration().Port()
+
+	for _, remote := range remoteConfigs() {
+		state := "none"
+		if detail := h.myService.PairingDetailForSki(remote.SKI); detail != nil {
+			state = pairingStateName(detail.State())
+		}
+		report.Remotes = append(report.Remotes, remoteStatus{
+			SKI:          shiputil.NormalizeSKI(remote.SKI),
+			Name:         remoteName(remote.SKI),
+			Connected:    remoteConnected(remote.SKI),
+			PairingState: state,
+		})
+	}
+
+	return report
+}
+
+// answer a status request
+func (h *hems) onStatusRequest(client mqtt.Client) {
+	payload, _ := json.Marshal(h.statusReport())
+	client.Publish(topicPrefix+statusCommand+ackTopicSuffix, qos, false, payload)
+}
+
+// query and print the status of the running bridge
+func runStatusCommand(args []string) int {
+	fs := commandFlags("status")
+	raw := fs.Bool("json", false, "print the status report as JSON")
+	timeout := fs.Duration("timeout", 5*time.Second, "time to wait for the MQTT broker and the bridge")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, "usage: status [-json] [-timeout <duration>]")
+		return 2
+	}
+
+	loadConfig(overrides...)
+	if err := setupSecrets(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+
+	c, err := mqttDial(*timeout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+	defer c.Disconnect(250)
+
+	if !bridgeOnline(c) {
+		fmt.Println("Bridge: offline")
+		return 1
+	}
+
+	data, ack, err := sendCommand(c, statusCommand, nil, *timeout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+	if !ack.Success {
+		fmt.Fprintln(os.Stderr, "Error:", ack.Error)
+		return 1
+	}
+
+	if *raw {
+		fmt.Println(string(data))
+		return 0
+	}
+
+	var report statusReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		fmt.Fprintln(os.Stderr, "Error: invalid status report:", err)
+		return 1
+	}
+	printStatus(os.Stdout, report)
+	return 0
+}
+
+// print a status report
+func printStatus(w io.Writer, report statusReport) {
+	fmt.Fprintf(w, "Bridge:    online since %s (%s)\n",
+		report.Started.Local().Format(time.DateTime), time.Since(report.Started).Round(time.Second))
+	fmt.Fprintf(w, "Local SKI: %s\n", report.LocalSKI)
+	fmt.Fprintf(w, "Port:      %d\n", report.Port)
+
+	for _, item := range []struct {
+		name   string
+		status *limitStatus
+	}{{"LPP", report.LPP}, {"LPC", report.LPC}} {
+		if item.status == nil {
+			continue
+		}
+		limit := ""
+		if item.status.Limit != nil {
+			limit = fmt.Sprintf(", limit %.0f W", *item.status.Limit)
+		}
+		fmt.Fprintf(w, "%s:       %s%s, last heartbeat %.0f s ago\n", item.name, item.status.State, limit, item.status.HeartbeatAge)
+	}
+
+	if len(report.Remotes) == 0 {
+		fmt.Fprintln(w, "Remotes:   none")
+		return
+	}
+	fmt.Fprintln(w, "Remotes:")
+	for _, remote := range report.Remotes {
+		connected := "disconnected"
+		if remote.Connected {
+			connected = "connected"
+		}
+		fmt.Fprintf(w, "  %s (%s): %s, pairing %s\n", remote.Name, remote.SKI, connected, remote.PairingState)
+	}
+}
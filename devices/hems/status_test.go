@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+func (s *HemsSuite) Test_PrintStatus() {
+	limit := 4200.0
+	report := statusReport{
+		Started:  time.Now().Add(-time.Hour),
+		LocalSKI: testSKI,
+		Port:     4713,
+		LPP:      &limitStatus{State: "unlimitedControlled", HeartbeatAge: 3},
+		LPC:      &limitStatus{State: "limited", Limit: &limit, HeartbeatAge: 12.4},
+		Remotes: []remoteStatus{
+			{SKI: testSKI, Name: "wallbox", Connected: true, PairingState: "completed"},
+			{SKI: otherSKI, Name: otherSKI, PairingState: "none"},
+		},
+	}
+
+	var w bytes.Buffer
+	printStatus(&w, report)
+
+	lines := strings.SplitAfterN(w.String(), "\n", 2)
+	s.Contains(lines[0], "Bridge:    online since "+report.Started.Local().Format(time.DateTime))
+	s.Equal("Local SKI: "+testSKI+"\n"+
+		"Port:      4713\n"+
+		"LPP:       unlimitedControlled, last heartbeat 3 s ago\n"+
+		"LPC:       limited, limit 4200 W, last heartbeat 12 s ago\n"+
+		"Remotes:\n"+
+		"  wallbox ("+testSKI+"): connected, pairing completed\n"+
+		"  "+otherSKI+" ("+otherSKI+"): disconnected, pairing none\n", lines[1])
+
+	// the use cases are not set up yet
+	w.Reset()
+	printStatus(&w, statusReport{Started: time.Now()})
+	s.True(strings.HasSuffix(w.String(), "Port:      0\nRemotes:   none\n"))
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	hemsconfig "github.com/enbility/eebus-go/devices/hems/config"
+	"github.com/enbility/eebus-go/devices/hems/logger"
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/stretchr/testify/suite"
+)
+
+const testSKI = "0123456789abcdef0123456789abcdef01234567"
+
+func TestHemsSuite(t *testing.T) {
+	suite.Run(t, new(HemsSuite))
+}
+
+type HemsSuite struct {
+	suite.Suite
+
+	client *fakeClient
+	lpp    *fakeLPP
+	lpc    *fakeLPC
+	sut    *hems
+}
+
+func (s *HemsSuite) BeforeTest(suiteName, testName string) {
+	s.client = &fakeClient{}
+	client = s.client
+	logs = logger.New(logger.Options{Output: io.Discard})
+
+	// a new config in the test directory
+	var err error
+	config, store, err = hemsconfig.Load(hemsconfig.Overrides{hemsconfig.DataDirKey: s.T().TempDir()})
+	s.Require().NotNil(store, err)
+
+	s.lpp = &fakeLPP{fakeLimits: fakeLimits{nominalMax: 10000, failsafeLimit: 4200, failsafeDuration: 2 * time.Hour}}
+	s.lpc = &fakeLPC{fakeLimits: fakeLimits{nominalMax: 32000, failsafeLimit: 4200, failsafeDuration: 2 * time.Hour}}
+	s.sut = &hems{uccslpp: s.lpp, uccslpc: s.lpc}
+}
+
+// the limits of a fake use case
+type fakeLimits struct {
+	nominalMax       float64
+	failsafeLimit    float64
+	failsafeDuration time.Duration
+}
+
+func (f *fakeLimits) FailsafeDurationMinimum() (time.Duration, bool, error) {
+	return f.failsafeDuration, true, nil
+}
+
+// the failsafe duration minimum has to be between 2 and 24 hours
+func (f *fakeLimits) SetFailsafeDurationMinimum(duration time.Duration, changeable bool) error {
+	if duration < 2*time.Hour || duration > 24*time.Hour {
+		return errors.New("duration outside of allowed range")
+	}
+	f.failsafeDuration = duration
+	return nil
+}
+
+// fakeLPP keeps the LPP limits, other methods are not supported
+type fakeLPP struct {
+	ucapi.CsLPPInterface
+	fakeLimits
+}
+
+func (f *fakeLPP) ProductionNominalMax() (float64, error) {
+	return f.nominalMax, nil
+}
+
+func (f *fakeLPP) SetProductionNominalMax(value float64) error {
+	f.nominalMax = value
+	return nil
+}
+
+func (f *fakeLPP) FailsafeProductionActivePowerLimit() (float64, bool, error) {
+	return f.failsafeLimit, true, nil
+}
+
+func (f *fakeLPP) SetFailsafeProductionActivePowerLimit(value float64, changeable bool) error {
+	f.failsafeLimit = value
+	return nil
+}
+
+func (f *fakeLPP) FailsafeDurationMinimum() (time.Duration, bool, error) {
+	return f.fakeLimits.FailsafeDurationMinimum()
+}
+
+func (f *fakeLPP) SetFailsafeDurationMinimum(duration time.Duration, changeable bool) error {
+	return f.fakeLimits.SetFailsafeDurationMinimum(duration, changeable)
+}
+
+// fakeLPC keeps the LPC limits, other methods are not supported
+type fakeLPC struct {
+	ucapi.CsLPCInterface
+	fakeLimits
+}
+
+func (f *fakeLPC) ConsumptionNominalMax() (float64, error) {
+	return f.nominalMax, nil
+}
+
+func (f *fakeLPC) SetConsumptionNominalMax(value float64) error {
+	f.nominalMax = value
+	return nil
+}
+
+func (f *fakeLPC) FailsafeConsumptionActivePowerLimit() (float64, bool, error) {
+	return f.failsafeLimit, true, nil
+}
+
+func (f *fakeLPC) SetFailsafeConsumptionActivePowerLimit(value float64, changeable bool) error {
+	f.failsafeLimit = value
+	return nil
+}
+
+func (f *fakeLPC) FailsafeDurationMinimum() (time.Duration, bool, error) {
+	return f.fakeLimits.FailsafeDurationMinimum()
+}
+
+func (f *fakeLPC) SetFailsafeDurationMinimum(duration time.Duration, changeable bool) error {
+	return f.fakeLimits.SetFailsafeDurationMinimum(duration, changeable)
+}
+
+// a published message
+type publication struct {
+	topic   string
+	payload string
+}
+
+// fakeClient records the publications, other methods are not supported
+type fakeClient struct {
+	mqtt.Client
+
+	mux       sync.Mutex
+	published []publication
+}
+
+func (c *fakeClient) Publish(topic string, qos byte, retained bool, payload interface{}) mqtt.Token {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	var text string
+	switch value := payload.(type) {
+	case string:
+		text = value
+	case []byte:
+		text = string(value)
+	}
+	c.published = append(c.published, publication{topic: topic, payload: text})
+	return &mqtt.DummyToken{}
+}
+
+// return the payloads published on a topic
+func (c *fakeClient) payloads(topic string) []string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	var payloads []string
+	for _, item := range c.published {
+		if item.topic == topic {
+			payloads = append(payloads, item.payload)
+		}
+	}
+	return payloads
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"github.com/enbility/eebus-go/api"
+)
+
+// Actor: Battery System
+// UseCase: Visualization of Aggregated Battery Data
+type BsVABDInterface interface {
+	api.UseCaseInterface
+
+	// Scenario 1
+
+	// set the current (dis)charging power
+	//
+	// parameters:
+	//   - power: the power in W, positive values for charging, negative values for discharging
+	SetPower(power float64) error
+
+	// Scenario 2
+
+	// set the cumulated battery system charge energy
+	//
+	// parameters:
+	//   - energy: the energy in Wh
+	SetEnergyCharged(energy float64) error
+
+	// Scenario 3
+
+	// set the cumulated battery system discharge energy
+	//
+	// parameters:
+	//   - energy: the energy in Wh
+	SetEnergyDischarged(energy float64) error
+
+	// Scenario 4
+
+	// set the current state of charge of the battery system
+	//
+	// parameters:
+	//   - soc: the state of charge in %
+	//
+	// possible errors:
+	//   - ErrDataInvalid if the value is not within 0 and 100
+	//   - and others
+	SetStateOfCharge(soc float64) error
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"github.com/enbility/eebus-go/api"
+)
+
+// Actor: Grid Connection Point
+// UseCase: Monitoring of Grid Connection Point
+type GcpMGCPInterface interface {
+	api.UseCaseInterface
+
+	// Scenario 1
+
+	// set the current power limitation factor
+	//
+	// parameters:
+	//   - factor: the power limitation factor in percent
+	SetPowerLimitationFactor(factor float64) error
+
+	// Scenario 2
+
+	// set the momentary power consumption or production at the grid connection point
+	//
+	// parameters:
+	//   - power: the power in W
+	//
+	// value semantics:
+	//   - positive values are used for consumption
+	//   - negative values are used for production
+	SetPower(power float64) error
+
+	// Scenario 3
+
+	// set the total feed in energy at the grid connection point
+	//
+	// parameters:
+	//   - energy: the energy in Wh
+	SetEnergyFeedIn(energy float64) error
+
+	// Scenario 4
+
+	// set the total consumption energy at the grid connection point
+	//
+	// parameters:
+	//   - energy: the energy in Wh
+	SetEnergyConsumed(energy float64) error
+
+	// Scenario 5
+
+	// set the momentary current consumption or production at the grid connection point
+	//
+	// parameters:
+	//   - currents: the currents in A, one value per phase in the order A, B, C
+	//
+	// value semantics:
+	//   - positive values are used for consumption
+	//   - negative values are used for production
+	SetCurrentPerPhase(currents []float64) error
+
+	// Scenario 6
+
+	// set the voltage phase details at the grid connection point
+	//
+	// parameters:
+	//   - voltages: the voltages in V, one value per phase in the order A, B, C
+	SetVoltagePerPhase(voltages []float64) error
+
+	// Scenario 7
+
+	// set the frequency at the grid connection point
+	//
+	// parameters:
+	//   - frequency: the frequency in Hz
+	SetFrequency(frequency float64) error
+}
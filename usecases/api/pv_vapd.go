@@ -0,0 +1,35 @@
+package api
+
+import (
+	"github.com/enbility/eebus-go/api"
+)
+
+// Actor: PV System
+// UseCase: Visualization of Aggregated Photovoltaic Data
+type PvVAPDInterface interface {
+	api.UseCaseInterface
+
+	// Scenario 1
+
+	// set the nominal peak power
+	//
+	// parameters:
+	//   - power: the nominal peak power in W
+	SetPowerNominalPeak(power float64) error
+
+	// Scenario 2
+
+	// set the current production power
+	//
+	// parameters:
+	//   - power: the production power in W
+	SetPower(power float64) error
+
+	// Scenario 3
+
+	// set total PV yield
+	//
+	// parameters:
+	//   - yield: the total yield in Wh
+	SetPVYieldTotal(yield float64) error
+}
@@ -0,0 +1,66 @@
+package vabd
+
+import (
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+)
+
+// Scenario 1
+
+// set the current battery (dis-)charge power (W)
+//
+//   - positive values charge power
+//   - negative values discharge power
+func (e *VABD) SetPower(power float64) error {
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypePower),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeACPowerTotal),
+	}
+	return e.updateMeasurementData(filter, power)
+}
+
+// Scenario 2
+
+// set the total charge energy (Wh)
+func (e *VABD) SetEnergyCharged(energy float64) error {
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypeEnergy),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeCharge),
+	}
+	return e.updateMeasurementData(filter, energy)
+}
+
+// Scenario 3
+
+// set the total discharge energy (Wh)
+func (e *VABD) SetEnergyDischarged(energy float64) error {
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypeEnergy),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeDischarge),
+	}
+	return e.updateMeasurementData(filter, energy)
+}
+
+// Scenario 4
+
+// set the current state of charge in %
+//
+// possible errors:
+//   - ErrDataInvalid if the value is not within 0 and 100
+//   - and others
+func (e *VABD) SetStateOfCharge(soc float64) error {
+	if soc < 0 || soc > 100 {
+		return api.ErrDataInvalid
+	}
+
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypePercentage),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeStateOfCharge),
+	}
+	return e.updateMeasurementData(filter, soc)
+}
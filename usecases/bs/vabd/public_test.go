@@ -0,0 +1,72 @@
+package vabd
+
+import (
+	"github.com/enbility/eebus-go/features/server"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *BsVABDSuite) measurementValue(scope model.ScopeTypeType) (float64, bool) {
+	measurement, err := server.NewMeasurement(s.sut.LocalEntity)
+	assert.Nil(s.T(), err)
+
+	filter := model.MeasurementDescriptionDataType{
+		ScopeType: util.Ptr(scope),
+	}
+	data, err := measurement.GetDataForFilter(filter)
+	if err != nil || len(data) != 1 || data[0].Value == nil {
+		return 0, false
+	}
+
+	return data[0].Value.GetValue(), true
+}
+
+func (s *BsVABDSuite) Test_SetPower() {
+	_, ok := s.measurementValue(model.ScopeTypeTypeACPowerTotal)
+	assert.False(s.T(), ok)
+
+	err := s.sut.SetPower(-2500)
+	assert.Nil(s.T(), err)
+
+	value, ok := s.measurementValue(model.ScopeTypeTypeACPowerTotal)
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), -2500.0, value)
+
+	s.sut.LocalEntity = nil
+	err = s.sut.SetPower(2500)
+	assert.NotNil(s.T(), err)
+}
+
+func (s *BsVABDSuite) Test_SetEnergyCharged() {
+	err := s.sut.SetEnergyCharged(1000)
+	assert.Nil(s.T(), err)
+
+	value, ok := s.measurementValue(model.ScopeTypeTypeCharge)
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), 1000.0, value)
+}
+
+func (s *BsVABDSuite) Test_SetEnergyDischarged() {
+	err := s.sut.SetEnergyDischarged(800)
+	assert.Nil(s.T(), err)
+
+	value, ok := s.measurementValue(model.ScopeTypeTypeDischarge)
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), 800.0, value)
+}
+
+func (s *BsVABDSuite) Test_SetStateOfCharge() {
+	err := s.sut.SetStateOfCharge(-1)
+	assert.NotNil(s.T(), err)
+
+	err = s.sut.SetStateOfCharge(101)
+	assert.NotNil(s.T(), err)
+
+	err = s.sut.SetStateOfCharge(55.5)
+	assert.Nil(s.T(), err)
+
+	value, ok := s.measurementValue(model.ScopeTypeTypeStateOfCharge)
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), 55.5, value)
+}
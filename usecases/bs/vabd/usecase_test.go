@@ -0,0 +1,37 @@
+package vabd
+
+import (
+	"github.com/enbility/eebus-go/features/server"
+	"github.com/enbility/spine-go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *BsVABDSuite) Test_AddFeatures() {
+	assert.NotNil(s.T(), s.measurementFeature)
+	assert.NotNil(s.T(), s.electricalConnectionFeature)
+
+	measurement, err := server.NewMeasurement(s.sut.LocalEntity)
+	assert.Nil(s.T(), err)
+
+	descs, err := measurement.GetDescriptionsForFilter(model.MeasurementDescriptionDataType{})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 4, len(descs))
+
+	ec, err := server.NewElectricalConnection(s.sut.LocalEntity)
+	assert.Nil(s.T(), err)
+
+	params, err := ec.GetParameterDescriptionsForFilter(model.ElectricalConnectionParameterDescriptionDataType{})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 3, len(params))
+
+	// adding the features again must not duplicate the descriptions
+	s.sut.AddFeatures()
+
+	descs, err = measurement.GetDescriptionsForFilter(model.MeasurementDescriptionDataType{})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 4, len(descs))
+}
+
+func (s *BsVABDSuite) Test_UpdateUseCaseAvailability() {
+	s.sut.UpdateUseCaseAvailability(true)
+}
@@ -0,0 +1,277 @@
+package cevc
+
+import (
+	"time"
+
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *CemCEVCSuite) Test_Events() {
+	payload := spineapi.EventPayload{
+		Entity: s.mockRemoteEntity,
+	}
+	s.sut.HandleEvent(payload)
+
+	payload.Entity = s.evEntity
+	s.sut.HandleEvent(payload)
+
+	payload.EventType = spineapi.EventTypeEntityChange
+	payload.ChangeType = spineapi.ElementChangeAdd
+	s.sut.HandleEvent(payload)
+
+	payload.ChangeType = spineapi.ElementChangeRemove
+	s.sut.HandleEvent(payload)
+
+	payload.EventType = spineapi.EventTypeDataChange
+	payload.ChangeType = spineapi.ElementChangeAdd
+	s.sut.HandleEvent(payload)
+
+	payload.EventType = spineapi.EventTypeDataChange
+	payload.ChangeType = spineapi.ElementChangeUpdate
+	payload.Data = util.Ptr(model.TimeSeriesDescriptionListDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.TimeSeriesConstraintsListDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.TimeSeriesListDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.IncentiveTableDescriptionDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.IncentiveTableConstraintsDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.IncentiveTableDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.NodeManagementUseCaseDataType{})
+	s.sut.HandleEvent(payload)
+}
+
+func (s *CemCEVCSuite) Test_Failures() {
+	s.sut.evConnected(s.mockRemoteEntity)
+
+	assert.False(s.T(), s.sut.evCheckTimeSeriesDescriptionConstraintsUpdateRequired(s.mockRemoteEntity))
+
+	assert.False(s.T(), s.sut.evCheckIncentiveTableDescriptionUpdateRequired(s.mockRemoteEntity))
+}
+
+func (s *CemCEVCSuite) Test_evTimeSeriesDescriptionDataUpdate() {
+	payload := spineapi.EventPayload{
+		Ski:    remoteSki,
+		Device: s.remoteDevice,
+		Entity: s.evEntity,
+	}
+	s.sut.evTimeSeriesDescriptionDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	descData := &model.TimeSeriesDescriptionListDataType{
+		TimeSeriesDescriptionData: []model.TimeSeriesDescriptionDataType{
+			{
+				TimeSeriesId:   util.Ptr(model.TimeSeriesIdType(0)),
+				TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypePlan),
+			},
+			{
+				TimeSeriesId:   util.Ptr(model.TimeSeriesIdType(1)),
+				TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypeSingleDemand),
+			},
+			{
+				TimeSeriesId:   util.Ptr(model.TimeSeriesIdType(2)),
+				TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypeConstraints),
+				UpdateRequired: util.Ptr(true),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeTimeSeries, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeTimeSeriesDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evTimeSeriesDescriptionDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	timeData := &model.TimeSeriesListDataType{
+		TimeSeriesData: []model.TimeSeriesDataType{
+			{
+				TimeSeriesId: util.Ptr(model.TimeSeriesIdType(1)),
+				TimePeriod: &model.TimePeriodType{
+					StartTime: model.NewAbsoluteOrRelativeTimeType("PT0S"),
+				},
+				TimeSeriesSlot: []model.TimeSeriesSlotType{
+					{
+						TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(0)),
+						Duration:         model.NewDurationType(time.Hour),
+						Value:            model.NewScaledNumberType(1000),
+					},
+				},
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeTimeSeriesListData, timeData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evTimeSeriesDescriptionDataUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+
+	constData := &model.TimeSeriesConstraintsListDataType{
+		TimeSeriesConstraintsData: []model.TimeSeriesConstraintsDataType{
+			{
+				TimeSeriesId: util.Ptr(model.TimeSeriesIdType(0)),
+				SlotCountMin: util.Ptr(model.TimeSeriesSlotCountType(1)),
+				SlotCountMax: util.Ptr(model.TimeSeriesSlotCountType(10)),
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeTimeSeriesConstraintsListData, constData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.eventCalled = false
+	s.sut.evTimeSeriesDescriptionDataUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+
+	incConstData := &model.IncentiveTableConstraintsDataType{
+		IncentiveTableConstraints: []model.IncentiveTableConstraintsType{
+			{
+				IncentiveSlotConstraints: &model.TimeTableConstraintsDataType{
+					SlotCountMin: util.Ptr(model.TimeSlotCountType(1)),
+					SlotCountMax: util.Ptr(model.TimeSlotCountType(10)),
+				},
+			},
+		},
+	}
+
+	rIncentiveFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeIncentiveTable, model.RoleTypeServer)
+	_, fErr = rIncentiveFeature.UpdateData(true, model.FunctionTypeIncentiveTableConstraintsData, incConstData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.eventCalled = false
+	s.sut.evTimeSeriesDescriptionDataUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+}
+
+func (s *CemCEVCSuite) Test_evTimeSeriesConstraintsDataUpdate() {
+	payload := spineapi.EventPayload{
+		Ski:    remoteSki,
+		Device: s.remoteDevice,
+		Entity: s.evEntity,
+	}
+	s.sut.evTimeSeriesConstraintsDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	constData := &model.TimeSeriesConstraintsListDataType{
+		TimeSeriesConstraintsData: []model.TimeSeriesConstraintsDataType{
+			{
+				TimeSeriesId: util.Ptr(model.TimeSeriesIdType(0)),
+				SlotCountMin: util.Ptr(model.TimeSeriesSlotCountType(1)),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeTimeSeries, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeTimeSeriesConstraintsListData, constData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evTimeSeriesConstraintsDataUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+}
+
+func (s *CemCEVCSuite) Test_evTimeSeriesDataUpdate() {
+	payload := spineapi.EventPayload{
+		Ski:    remoteSki,
+		Device: s.remoteDevice,
+		Entity: s.evEntity,
+	}
+	s.sut.evTimeSeriesDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	descData := &model.TimeSeriesDescriptionListDataType{
+		TimeSeriesDescriptionData: []model.TimeSeriesDescriptionDataType{
+			{
+				TimeSeriesId:   util.Ptr(model.TimeSeriesIdType(0)),
+				TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypePlan),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeTimeSeries, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeTimeSeriesDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evTimeSeriesDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	timeData := &model.TimeSeriesListDataType{
+		TimeSeriesData: []model.TimeSeriesDataType{
+			{
+				TimeSeriesId: util.Ptr(model.TimeSeriesIdType(0)),
+				TimeSeriesSlot: []model.TimeSeriesSlotType{
+					{
+						TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(0)),
+						Duration:         model.NewDurationType(time.Hour),
+						MaxValue:         model.NewScaledNumberType(4201),
+					},
+				},
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeTimeSeriesListData, timeData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evTimeSeriesDataUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+}
+
+func (s *CemCEVCSuite) Test_evIncentiveTableDescriptionDataUpdate() {
+	payload := spineapi.EventPayload{
+		Ski:    remoteSki,
+		Device: s.remoteDevice,
+		Entity: s.evEntity,
+	}
+	s.sut.evIncentiveTableDescriptionDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	descData := &model.IncentiveTableDescriptionDataType{
+		IncentiveTableDescription: []model.IncentiveTableDescriptionType{
+			{
+				TariffDescription: &model.TariffDescriptionDataType{
+					TariffId:        util.Ptr(model.TariffIdType(0)),
+					TariffWriteable: util.Ptr(true),
+					UpdateRequired:  util.Ptr(false),
+					ScopeType:       util.Ptr(model.ScopeTypeTypeSimpleIncentiveTable),
+				},
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeIncentiveTable, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeIncentiveTableDescriptionData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evIncentiveTableDescriptionDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	descData.IncentiveTableDescription[0].TariffDescription.UpdateRequired = util.Ptr(true)
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeIncentiveTableDescriptionData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evIncentiveTableDescriptionDataUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+}
+
+func (s *CemCEVCSuite) Test_evIncentiveTableDataUpdate() {
+	payload := spineapi.EventPayload{
+		Ski:    remoteSki,
+		Device: s.remoteDevice,
+		Entity: s.evEntity,
+	}
+	s.sut.evIncentiveTableDataUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+}
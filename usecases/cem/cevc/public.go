@@ -0,0 +1,765 @@
+package cevc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/features/client"
+	"github.com/enbility/eebus-go/features/server"
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/enbility/ship-go/logging"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+)
+
+// Scenario 1
+
+// returns the current charging strategy
+func (e *CEVC) ChargeStrategy(entity spineapi.EntityRemoteInterface) ucapi.EVChargeStrategyType {
+	if !e.IsCompatibleEntityType(entity) {
+		return ucapi.EVChargeStrategyTypeUnknown
+	}
+
+	// only ISO communication can provide a charging strategy information
+	com, err := e.communicationStandard(entity)
+	if err != nil || com == model.DeviceConfigurationKeyValueStringTypeIEC61851 {
+		return ucapi.EVChargeStrategyTypeUnknown
+	}
+
+	evTimeSeries, err := client.NewTimeSeries(e.LocalEntity, entity)
+	if err != nil {
+		return ucapi.EVChargeStrategyTypeUnknown
+	}
+
+	// get the data for the demand time series
+	filter := model.TimeSeriesDescriptionDataType{
+		TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypeSingleDemand),
+	}
+	data, err := evTimeSeries.GetDataForFilter(filter)
+	if err != nil || len(data) == 0 {
+		return ucapi.EVChargeStrategyTypeUnknown
+	}
+
+	// without time series slots, there is no known strategy
+	if len(data[0].TimeSeriesSlot) == 0 {
+		return ucapi.EVChargeStrategyTypeUnknown
+	}
+
+	// get the value for the first slot
+	firstSlot := data[0].TimeSeriesSlot[0]
+
+	if firstSlot.Duration == nil {
+		// if value is > 0 and duration does not exist, the EV is direct charging
+		if firstSlot.Value != nil && firstSlot.Value.GetValue() > 0 {
+			return ucapi.EVChargeStrategyTypeDirectCharging
+		}
+
+		// maxValue will show the maximum amount the battery could take
+		return ucapi.EVChargeStrategyTypeNoDemand
+	}
+
+	if _, err := firstSlot.Duration.GetTimeDuration(); err != nil {
+		// we got an invalid duration
+		return ucapi.EVChargeStrategyTypeUnknown
+	}
+
+	if firstSlot.MinValue != nil && firstSlot.MinValue.GetValue() > 0 {
+		return ucapi.EVChargeStrategyTypeMinSoC
+	}
+
+	if firstSlot.Value != nil {
+		if firstSlot.Value.GetValue() > 0 {
+			// there is demand and a duration
+			return ucapi.EVChargeStrategyTypeTimedCharging
+		}
+
+		return ucapi.EVChargeStrategyTypeNoDemand
+	}
+
+	return ucapi.EVChargeStrategyTypeUnknown
+}
+
+// returns the current energy demand
+//   - EVDemand: details about the actual demands from the EV
+//   - error: if no data is available
+//
+// if duration is 0, direct charging is active, otherwise timed charging is active
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such data is (yet) available
+//   - and others
+func (e *CEVC) EnergyDemand(entity spineapi.EntityRemoteInterface) (ucapi.Demand, error) {
+	demand := ucapi.Demand{}
+
+	if !e.IsCompatibleEntityType(entity) {
+		return demand, api.ErrNoCompatibleEntity
+	}
+
+	evTimeSeries, err := client.NewTimeSeries(e.LocalEntity, entity)
+	if err != nil {
+		return demand, api.ErrDataNotAvailable
+	}
+
+	filter := model.TimeSeriesDescriptionDataType{
+		TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypeSingleDemand),
+	}
+	data, err := evTimeSeries.GetDataForFilter(filter)
+	if err != nil || len(data) == 0 {
+		return demand, api.ErrDataNotAvailable
+	}
+
+	// we need at least a time series slot
+	if len(data[0].TimeSeriesSlot) == 0 {
+		return demand, api.ErrDataNotAvailable
+	}
+
+	// get the value for the first slot, ignore all others, which
+	// in the tests so far always have min/max/value 0
+	firstSlot := data[0].TimeSeriesSlot[0]
+	if firstSlot.MinValue != nil {
+		demand.MinDemand = firstSlot.MinValue.GetValue()
+	}
+	if firstSlot.Value != nil {
+		demand.OptDemand = firstSlot.Value.GetValue()
+	}
+	if firstSlot.MaxValue != nil {
+		demand.MaxDemand = firstSlot.MaxValue.GetValue()
+	}
+	if firstSlot.Duration != nil {
+		if tempDuration, err := firstSlot.Duration.GetTimeDuration(); err == nil {
+			demand.DurationUntilEnd = tempDuration.Seconds()
+		}
+	}
+
+	// start time has to be defined either in TimePeriod or the first slot
+	relStartTime := time.Duration(0)
+
+	startTimeSet := false
+	if data[0].TimePeriod != nil && data[0].TimePeriod.StartTime != nil {
+		if temp, err := data[0].TimePeriod.StartTime.GetTimeDuration(); err == nil {
+			relStartTime = temp
+			startTimeSet = true
+		}
+	}
+
+	if !startTimeSet &&
+		firstSlot.TimePeriod != nil && firstSlot.TimePeriod.StartTime != nil {
+		if temp, err := firstSlot.TimePeriod.StartTime.GetTimeDuration(); err == nil {
+			relStartTime = temp
+		}
+	}
+
+	demand.DurationUntilStart = relStartTime.Seconds()
+
+	return demand, nil
+}
+
+// Scenario 2
+
+// returns the constraints for the time slots
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such data is (yet) available
+//   - and others
+func (e *CEVC) TimeSlotConstraints(entity spineapi.EntityRemoteInterface) (ucapi.TimeSlotConstraints, error) {
+	result := ucapi.TimeSlotConstraints{}
+
+	if !e.IsCompatibleEntityType(entity) {
+		return result, api.ErrNoCompatibleEntity
+	}
+
+	evTimeSeries, err := client.NewTimeSeries(e.LocalEntity, entity)
+	if err != nil {
+		return result, api.ErrDataNotAvailable
+	}
+
+	constraints, err := evTimeSeries.GetConstraints()
+	if err != nil || len(constraints) == 0 {
+		return result, api.ErrDataNotAvailable
+	}
+
+	constraint := constraints[0]
+
+	if constraint.SlotCountMin != nil {
+		result.MinSlots = uint(*constraint.SlotCountMin)
+	}
+	if constraint.SlotCountMax != nil {
+		result.MaxSlots = uint(*constraint.SlotCountMax)
+	}
+	if constraint.SlotDurationMin != nil {
+		if duration, err := constraint.SlotDurationMin.GetTimeDuration(); err == nil {
+			result.MinSlotDuration = duration
+		}
+	}
+	if constraint.SlotDurationMax != nil {
+		if duration, err := constraint.SlotDurationMax.GetTimeDuration(); err == nil {
+			result.MaxSlotDuration = duration
+		}
+	}
+	if constraint.SlotDurationStepSize != nil {
+		if duration, err := constraint.SlotDurationStepSize.GetTimeDuration(); err == nil {
+			result.SlotDurationStepSize = duration
+		}
+	}
+
+	return result, nil
+}
+
+// send power limits to the EV
+// if no data is provided, default power limits with the max possible value for 7 days will be sent
+func (e *CEVC) WritePowerLimits(entity spineapi.EntityRemoteInterface, data []ucapi.DurationSlotValue) error {
+	if !e.IsCompatibleEntityType(entity) {
+		return api.ErrNoCompatibleEntity
+	}
+
+	evTimeSeries, err := client.NewTimeSeries(e.LocalEntity, entity)
+	if err != nil {
+		return api.ErrDataNotAvailable
+	}
+
+	if len(data) == 0 {
+		data, err = e.defaultPowerLimits(entity)
+		if err != nil {
+			return err
+		}
+	}
+
+	constraints, err := e.TimeSlotConstraints(entity)
+	if err != nil {
+		return err
+	}
+
+	if constraints.MinSlots != 0 && constraints.MinSlots > uint(len(data)) {
+		return errors.New("too few charge slots provided")
+	}
+
+	if constraints.MaxSlots != 0 && constraints.MaxSlots < uint(len(data)) {
+		return errors.New("too many charge slots provided")
+	}
+
+	filter := model.TimeSeriesDescriptionDataType{
+		TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypeConstraints),
+	}
+	desc, err := evTimeSeries.GetDescriptionsForFilter(filter)
+	if err != nil || len(desc) == 0 || desc[0].TimeSeriesId == nil {
+		return api.ErrDataNotAvailable
+	}
+
+	timeSeriesSlots := []model.TimeSeriesSlotType{}
+	var totalDuration time.Duration
+	for index, slot := range data {
+		relativeStart := totalDuration
+
+		timeSeriesSlot := model.TimeSeriesSlotType{
+			TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(index)),
+			TimePeriod: &model.TimePeriodType{
+				StartTime: model.NewAbsoluteOrRelativeTimeTypeFromDuration(relativeStart),
+			},
+			MaxValue: model.NewScaledNumberType(slot.Value),
+		}
+
+		// the last slot also needs an End Time
+		if index == len(data)-1 {
+			relativeEndTime := relativeStart + slot.Duration
+			timeSeriesSlot.TimePeriod.EndTime = model.NewAbsoluteOrRelativeTimeTypeFromDuration(relativeEndTime)
+		}
+		timeSeriesSlots = append(timeSeriesSlots, timeSeriesSlot)
+
+		totalDuration += slot.Duration
+	}
+
+	timeSeriesData := model.TimeSeriesDataType{
+		TimeSeriesId: desc[0].TimeSeriesId,
+		TimePeriod: &model.TimePeriodType{
+			StartTime: model.NewAbsoluteOrRelativeTimeType("PT0S"),
+			EndTime:   model.NewAbsoluteOrRelativeTimeTypeFromDuration(totalDuration),
+		},
+		TimeSeriesSlot: timeSeriesSlots,
+	}
+
+	_, err = evTimeSeries.WriteData([]model.TimeSeriesDataType{timeSeriesData})
+
+	return err
+}
+
+// Scenario 3
+
+// returns the minimum and maximum number of incentive slots allowed
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such data is (yet) available
+//   - and others
+func (e *CEVC) IncentiveConstraints(entity spineapi.EntityRemoteInterface) (ucapi.IncentiveSlotConstraints, error) {
+	result := ucapi.IncentiveSlotConstraints{}
+
+	if !e.IsCompatibleEntityType(entity) {
+		return result, api.ErrNoCompatibleEntity
+	}
+
+	evIncentiveTable, err := client.NewIncentiveTable(e.LocalEntity, entity)
+	if err != nil {
+		return result, api.ErrDataNotAvailable
+	}
+
+	constraints, err := evIncentiveTable.GetConstraints()
+	if err != nil || len(constraints) == 0 {
+		return result, api.ErrDataNotAvailable
+	}
+
+	// only use the first constraint
+	constraint := constraints[0]
+
+	if constraint.IncentiveSlotConstraints != nil {
+		if constraint.IncentiveSlotConstraints.SlotCountMin != nil {
+			result.MinSlots = uint(*constraint.IncentiveSlotConstraints.SlotCountMin)
+		}
+		if constraint.IncentiveSlotConstraints.SlotCountMax != nil {
+			result.MaxSlots = uint(*constraint.IncentiveSlotConstraints.SlotCountMax)
+		}
+	}
+
+	return result, nil
+}
+
+// inform the EVSE about used currency and boundary units
+//
+// SPINE UC CoordinatedEVCharging 2.4.3
+func (e *CEVC) WriteIncentiveTableDescriptions(entity spineapi.EntityRemoteInterface, data []ucapi.IncentiveTariffDescription) error {
+	if !e.IsCompatibleEntityType(entity) {
+		return api.ErrNoCompatibleEntity
+	}
+
+	evIncentiveTable, err := client.NewIncentiveTable(e.LocalEntity, entity)
+	if err != nil {
+		return api.ErrDataNotAvailable
+	}
+
+	filter := model.TariffDescriptionDataType{
+		ScopeType: util.Ptr(model.ScopeTypeTypeSimpleIncentiveTable),
+	}
+	descData, err := evIncentiveTable.GetDescriptionsForFilter(filter)
+	if err != nil || len(descData) == 0 {
+		return api.ErrDataNotAvailable
+	}
+
+	// default tariff
+	//
+	// - tariff, min 1
+	//   each tariff has
+	//   - tiers: min 1, max 3
+	//     each tier has:
+	//     - boundaries: min 1, used for different power limits, e.g. 0-1kW x€, 1-3kW y€, ...
+	//     - incentives: min 1, max 3
+	//       - price/costs (absolute or relative)
+	//       - renewable energy percentage
+	//       - CO2 emissions
+	//
+	// limit this to
+	// - 1 tariff
+	//   - 1 tier
+	//     - 1 boundary
+	//     - 1 incentive (price)
+	//       incentive type has to be the same for all sent power limits!
+	if len(data) == 0 {
+		data = []ucapi.IncentiveTariffDescription{
+			{
+				Tiers: []ucapi.IncentiveTableDescriptionTier{
+					{
+						Id:   0,
+						Type: model.TierTypeTypeDynamicCost,
+						Boundaries: []ucapi.TierBoundaryDescription{
+							{
+								Id:   0,
+								Type: model.TierBoundaryTypeTypePowerBoundary,
+								Unit: model.UnitOfMeasurementTypeW,
+							},
+						},
+						Incentives: []ucapi.IncentiveDescription{
+							{
+								Id:       0,
+								Type:     model.IncentiveTypeTypeAbsoluteCost,
+								Currency: model.CurrencyTypeEur,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	var incentiveTableDescription []model.IncentiveTableDescriptionType
+
+	for index, tariff := range data {
+		// use the matching tariff description of the EV, fall back to the first one
+		tariffDesc := descData[0].TariffDescription
+		if index < len(descData) {
+			tariffDesc = descData[index].TariffDescription
+		}
+
+		tariffDescription := model.IncentiveTableDescriptionType{
+			TariffDescription: tariffDesc,
+		}
+
+		for _, tier := range tariff.Tiers {
+			newTier := model.IncentiveTableDescriptionTierType{
+				TierDescription: &model.TierDescriptionDataType{
+					TierId:   util.Ptr(model.TierIdType(tier.Id)),
+					TierType: util.Ptr(tier.Type),
+				},
+			}
+
+			for _, boundary := range tier.Boundaries {
+				newBoundary := model.TierBoundaryDescriptionDataType{
+					BoundaryId:   util.Ptr(model.TierBoundaryIdType(boundary.Id)),
+					BoundaryType: util.Ptr(boundary.Type),
+					BoundaryUnit: util.Ptr(boundary.Unit),
+				}
+				newTier.BoundaryDescription = append(newTier.BoundaryDescription, newBoundary)
+			}
+
+			for _, incentive := range tier.Incentives {
+				newIncentive := model.IncentiveDescriptionDataType{
+					IncentiveId:   util.Ptr(model.IncentiveIdType(incentive.Id)),
+					IncentiveType: util.Ptr(incentive.Type),
+				}
+				if incentive.Currency != "" {
+					newIncentive.Currency = util.Ptr(incentive.Currency)
+				}
+				newTier.IncentiveDescription = append(newTier.IncentiveDescription, newIncentive)
+			}
+
+			tariffDescription.Tier = append(tariffDescription.Tier, newTier)
+		}
+
+		incentiveTableDescription = append(incentiveTableDescription, tariffDescription)
+	}
+
+	_, err = evIncentiveTable.WriteDescriptions(incentiveTableDescription)
+
+	return err
+}
+
+// send incentives to the EV
+// if no data is provided, default incentives with the same price for 7 days will be sent
+func (e *CEVC) WriteIncentives(entity spineapi.EntityRemoteInterface, data []ucapi.DurationSlotValue) error {
+	if !e.IsCompatibleEntityType(entity) {
+		return api.ErrNoCompatibleEntity
+	}
+
+	evIncentiveTable, err := client.NewIncentiveTable(e.LocalEntity, entity)
+	if err != nil {
+		return api.ErrDataNotAvailable
+	}
+
+	if len(data) == 0 {
+		// send default incentives for the maximum timeframe
+		// to fullfill spec, as there is no data provided
+		logging.Log().Info("Fallback sending default incentives")
+		data = []ucapi.DurationSlotValue{
+			{Duration: 7 * time.Hour * 24, Value: 0.30},
+		}
+	}
+
+	constraints, err := e.IncentiveConstraints(entity)
+	if err != nil {
+		return err
+	}
+
+	if constraints.MinSlots != 0 && constraints.MinSlots > uint(len(data)) {
+		return errors.New("too few charge slots provided")
+	}
+
+	if constraints.MaxSlots != 0 && constraints.MaxSlots < uint(len(data)) {
+		return errors.New("too many charge slots provided")
+	}
+
+	incentiveSlots := []model.IncentiveTableIncentiveSlotType{}
+	var totalDuration time.Duration
+	for index, slot := range data {
+		relativeStart := totalDuration
+
+		timeInterval := &model.TimeTableDataType{
+			StartTime: &model.AbsoluteOrRecurringTimeType{
+				Relative: model.NewDurationType(relativeStart),
+			},
+		}
+
+		// the last slot also needs an End Time
+		if index == len(data)-1 {
+			relativeEndTime := relativeStart + slot.Duration
+			timeInterval.EndTime = &model.AbsoluteOrRecurringTimeType{
+				Relative: model.NewDurationType(relativeEndTime),
+			}
+		}
+
+		incentiveSlot := model.IncentiveTableIncentiveSlotType{
+			TimeInterval: timeInterval,
+			Tier: []model.IncentiveTableTierType{
+				{
+					Tier: &model.TierDataType{
+						TierId: util.Ptr(model.TierIdType(0)),
+					},
+					Boundary: []model.TierBoundaryDataType{
+						{
+							BoundaryId:         util.Ptr(model.TierBoundaryIdType(0)),
+							LowerBoundaryValue: model.NewScaledNumberType(0),
+						},
+					},
+					Incentive: []model.IncentiveDataType{
+						{
+							IncentiveId: util.Ptr(model.IncentiveIdType(0)),
+							Value:       model.NewScaledNumberType(slot.Value),
+						},
+					},
+				},
+			},
+		}
+		incentiveSlots = append(incentiveSlots, incentiveSlot)
+
+		totalDuration += slot.Duration
+	}
+
+	incentiveData := model.IncentiveTableType{
+		Tariff: &model.TariffDataType{
+			TariffId: util.Ptr(model.TariffIdType(0)),
+		},
+		IncentiveSlot: incentiveSlots,
+	}
+
+	_, err = evIncentiveTable.WriteValues([]model.IncentiveTableType{incentiveData})
+
+	return err
+}
+
+// Scenario 4
+
+// returns the current charge plan constraints of the EV
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such data is (yet) available
+//   - and others
+func (e *CEVC) ChargePlanConstraints(entity spineapi.EntityRemoteInterface) ([]ucapi.DurationSlotValue, error) {
+	if !e.IsCompatibleEntityType(entity) {
+		return nil, api.ErrNoCompatibleEntity
+	}
+
+	evTimeSeries, err := client.NewTimeSeries(e.LocalEntity, entity)
+	if err != nil {
+		return nil, api.ErrDataNotAvailable
+	}
+
+	filter := model.TimeSeriesDescriptionDataType{
+		TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypeConstraints),
+	}
+	data, err := evTimeSeries.GetDataForFilter(filter)
+	if err != nil || len(data) == 0 {
+		return nil, api.ErrDataNotAvailable
+	}
+
+	// we need at least a time series slot
+	if len(data[0].TimeSeriesSlot) == 0 {
+		return nil, api.ErrDataNotAvailable
+	}
+
+	var result []ucapi.DurationSlotValue
+	for _, slot := range data[0].TimeSeriesSlot {
+		newSlot := ucapi.DurationSlotValue{}
+
+		if slot.Duration != nil {
+			if duration, err := slot.Duration.GetTimeDuration(); err == nil {
+				newSlot.Duration = duration
+			}
+		} else if slot.TimePeriod != nil && slot.TimePeriod.EndTime != nil {
+			var start time.Duration
+			if slot.TimePeriod.StartTime != nil {
+				start, _ = slot.TimePeriod.StartTime.GetTimeDuration()
+			}
+			if end, err := slot.TimePeriod.EndTime.GetTimeDuration(); err == nil && end > start {
+				newSlot.Duration = end - start
+			}
+		}
+
+		if slot.MaxValue != nil {
+			newSlot.Value = slot.MaxValue.GetValue()
+		}
+
+		result = append(result, newSlot)
+	}
+
+	return result, nil
+}
+
+// returns the current charge plan of the EV
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such data is (yet) available
+//   - and others
+func (e *CEVC) ChargePlan(entity spineapi.EntityRemoteInterface) (ucapi.ChargePlan, error) {
+	plan := ucapi.ChargePlan{}
+
+	if !e.IsCompatibleEntityType(entity) {
+		return plan, api.ErrNoCompatibleEntity
+	}
+
+	evTimeSeries, err := client.NewTimeSeries(e.LocalEntity, entity)
+	if err != nil {
+		return plan, api.ErrDataNotAvailable
+	}
+
+	filter := model.TimeSeriesDescriptionDataType{
+		TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypePlan),
+	}
+	data, err := evTimeSeries.GetDataForFilter(filter)
+	if err != nil || len(data) == 0 {
+		return plan, api.ErrDataNotAvailable
+	}
+
+	// we need at least a time series slot
+	if len(data[0].TimeSeriesSlot) == 0 {
+		return plan, api.ErrDataNotAvailable
+	}
+
+	// the start time of the plan is relative to now
+	planStart := time.Now()
+	if data[0].TimePeriod != nil && data[0].TimePeriod.StartTime != nil {
+		if start, err := data[0].TimePeriod.StartTime.GetTimeDuration(); err == nil {
+			planStart = planStart.Add(start)
+		}
+	}
+
+	slotStart := planStart
+	for _, slot := range data[0].TimeSeriesSlot {
+		newSlot := ucapi.ChargePlanSlotValue{
+			Start: slotStart,
+			End:   slotStart,
+		}
+
+		if slot.TimePeriod != nil {
+			if slot.TimePeriod.StartTime != nil {
+				if start, err := slot.TimePeriod.StartTime.GetTimeDuration(); err == nil {
+					newSlot.Start = planStart.Add(start)
+				}
+			}
+			if slot.TimePeriod.EndTime != nil {
+				if end, err := slot.TimePeriod.EndTime.GetTimeDuration(); err == nil {
+					newSlot.End = planStart.Add(end)
+				}
+			}
+		}
+
+		if slot.Duration != nil {
+			if duration, err := slot.Duration.GetTimeDuration(); err == nil {
+				newSlot.End = newSlot.Start.Add(duration)
+			}
+		}
+
+		if slot.Value != nil {
+			newSlot.Value = slot.Value.GetValue()
+		}
+		if slot.MinValue != nil {
+			newSlot.MinValue = slot.MinValue.GetValue()
+		}
+		if slot.MaxValue != nil {
+			newSlot.MaxValue = slot.MaxValue.GetValue()
+		}
+
+		plan.Slots = append(plan.Slots, newSlot)
+
+		slotStart = newSlot.End
+	}
+
+	return plan, nil
+}
+
+// Scenario 5 & 6
+
+// start sending heartbeat from the local CEM entity
+//
+// the heartbeat is started by default when a non 0 timeout is set in the service configuration
+func (e *CEVC) StartHeartbeat() {
+	if hm := e.LocalEntity.HeartbeatManager(); hm != nil {
+		_ = hm.StartHeartbeat()
+	}
+}
+
+// stop sending heartbeat from the local CEM entity
+func (e *CEVC) StopHeartbeat() {
+	if hm := e.LocalEntity.HeartbeatManager(); hm != nil {
+		hm.StopHeartbeat()
+	}
+}
+
+// Scenario 7 & 8
+
+// set the local operating state of the local cem entity
+//
+// parameters:
+//   - failureState: if true, the operating state is set to failure, otherwise to normal
+func (e *CEVC) SetOperatingState(failureState bool) error {
+	lf, err := server.NewDeviceDiagnosis(e.LocalEntity)
+	if err != nil {
+		return err
+	}
+
+	state := model.DeviceDiagnosisOperatingStateTypeNormalOperation
+	if failureState {
+		state = model.DeviceDiagnosisOperatingStateTypeFailure
+	}
+	lf.SetLocalOperatingState(state)
+
+	return nil
+}
+
+// return the communication standard used between the EVSE and the EV
+func (e *CEVC) communicationStandard(entity spineapi.EntityRemoteInterface) (model.DeviceConfigurationKeyValueStringType, error) {
+	evDeviceConfiguration, err := client.NewDeviceConfiguration(e.LocalEntity, entity)
+	if err != nil {
+		return "", err
+	}
+
+	filter := model.DeviceConfigurationKeyValueDescriptionDataType{
+		KeyName:   util.Ptr(model.DeviceConfigurationKeyNameTypeCommunicationsStandard),
+		ValueType: util.Ptr(model.DeviceConfigurationKeyValueTypeTypeString),
+	}
+	data, err := evDeviceConfiguration.GetKeyValueDataForFilter(filter)
+	if err != nil || data == nil || data.Value == nil || data.Value.String == nil {
+		return "", api.ErrDataNotAvailable
+	}
+
+	return *data.Value.String, nil
+}
+
+// build default power limits using the maximum permitted power for 7 days
+func (e *CEVC) defaultPowerLimits(entity spineapi.EntityRemoteInterface) ([]ucapi.DurationSlotValue, error) {
+	// send default power limits for the maximum timeframe
+	// to fullfill spec, as there is no data provided
+	logging.Log().Info("Fallback sending default power limits")
+
+	evElectricalConnection, err := client.NewElectricalConnection(e.LocalEntity, entity)
+	if err != nil {
+		return nil, api.ErrDataNotAvailable
+	}
+
+	paramFilter := model.ElectricalConnectionParameterDescriptionDataType{
+		ScopeType: util.Ptr(model.ScopeTypeTypeACPowerTotal),
+	}
+	paramDesc, err := evElectricalConnection.GetParameterDescriptionsForFilter(paramFilter)
+	if err != nil || len(paramDesc) == 0 || paramDesc[0].ParameterId == nil {
+		return nil, api.ErrDataNotAvailable
+	}
+
+	filter := model.ElectricalConnectionPermittedValueSetDataType{
+		ElectricalConnectionId: paramDesc[0].ElectricalConnectionId,
+		ParameterId:            paramDesc[0].ParameterId,
+	}
+	_, maxValue, _, err := evElectricalConnection.GetPermittedValueDataForFilter(filter)
+	if err != nil || maxValue == 0 {
+		return nil, api.ErrDataNotAvailable
+	}
+
+	return []ucapi.DurationSlotValue{
+		{Duration: 7 * time.Hour * 24, Value: maxValue},
+	}, nil
+}
@@ -0,0 +1,636 @@
+package cevc
+
+import (
+	"time"
+
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *CemCEVCSuite) Test_ChargeStrategy() {
+	data := s.sut.ChargeStrategy(s.mockRemoteEntity)
+	assert.Equal(s.T(), ucapi.EVChargeStrategyTypeUnknown, data)
+
+	data = s.sut.ChargeStrategy(s.evEntity)
+	assert.Equal(s.T(), ucapi.EVChargeStrategyTypeUnknown, data)
+
+	descData := &model.DeviceConfigurationKeyValueDescriptionListDataType{
+		DeviceConfigurationKeyValueDescriptionData: []model.DeviceConfigurationKeyValueDescriptionDataType{
+			{
+				KeyId:     util.Ptr(model.DeviceConfigurationKeyIdType(0)),
+				KeyName:   util.Ptr(model.DeviceConfigurationKeyNameTypeCommunicationsStandard),
+				ValueType: util.Ptr(model.DeviceConfigurationKeyValueTypeTypeString),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeDeviceConfiguration, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeDeviceConfigurationKeyValueDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	keyData := &model.DeviceConfigurationKeyValueListDataType{
+		DeviceConfigurationKeyValueData: []model.DeviceConfigurationKeyValueDataType{
+			{
+				KeyId: util.Ptr(model.DeviceConfigurationKeyIdType(0)),
+				Value: &model.DeviceConfigurationKeyValueValueType{
+					String: util.Ptr(model.DeviceConfigurationKeyValueStringTypeIEC61851),
+				},
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeDeviceConfigurationKeyValueListData, keyData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data = s.sut.ChargeStrategy(s.evEntity)
+	assert.Equal(s.T(), ucapi.EVChargeStrategyTypeUnknown, data)
+
+	keyData.DeviceConfigurationKeyValueData[0].Value.String = util.Ptr(model.DeviceConfigurationKeyValueStringTypeISO151182ED2)
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeDeviceConfigurationKeyValueListData, keyData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data = s.sut.ChargeStrategy(s.evEntity)
+	assert.Equal(s.T(), ucapi.EVChargeStrategyTypeUnknown, data)
+
+	timeDescData := &model.TimeSeriesDescriptionListDataType{
+		TimeSeriesDescriptionData: []model.TimeSeriesDescriptionDataType{
+			{
+				TimeSeriesId:   util.Ptr(model.TimeSeriesIdType(1)),
+				TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypeSingleDemand),
+			},
+		},
+	}
+
+	rTimeFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeTimeSeries, model.RoleTypeServer)
+	_, fErr = rTimeFeature.UpdateData(true, model.FunctionTypeTimeSeriesDescriptionListData, timeDescData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	timeData := &model.TimeSeriesListDataType{
+		TimeSeriesData: []model.TimeSeriesDataType{
+			{
+				TimeSeriesId: util.Ptr(model.TimeSeriesIdType(1)),
+			},
+		},
+	}
+
+	_, fErr = rTimeFeature.UpdateData(true, model.FunctionTypeTimeSeriesListData, timeData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data = s.sut.ChargeStrategy(s.evEntity)
+	assert.Equal(s.T(), ucapi.EVChargeStrategyTypeUnknown, data)
+
+	tests := []struct {
+		slot     model.TimeSeriesSlotType
+		strategy ucapi.EVChargeStrategyType
+	}{
+		{
+			model.TimeSeriesSlotType{
+				TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(0)),
+			},
+			ucapi.EVChargeStrategyTypeNoDemand,
+		},
+		{
+			model.TimeSeriesSlotType{
+				TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(0)),
+				Value:            model.NewScaledNumberType(10000),
+			},
+			ucapi.EVChargeStrategyTypeDirectCharging,
+		},
+		{
+			model.TimeSeriesSlotType{
+				TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(0)),
+				Duration:         util.Ptr(model.DurationType("invalid")),
+			},
+			ucapi.EVChargeStrategyTypeUnknown,
+		},
+		{
+			model.TimeSeriesSlotType{
+				TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(0)),
+				Duration:         model.NewDurationType(2 * time.Hour),
+				MinValue:         model.NewScaledNumberType(1000),
+			},
+			ucapi.EVChargeStrategyTypeMinSoC,
+		},
+		{
+			model.TimeSeriesSlotType{
+				TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(0)),
+				Duration:         model.NewDurationType(2 * time.Hour),
+				Value:            model.NewScaledNumberType(10000),
+			},
+			ucapi.EVChargeStrategyTypeTimedCharging,
+		},
+		{
+			model.TimeSeriesSlotType{
+				TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(0)),
+				Duration:         model.NewDurationType(2 * time.Hour),
+				Value:            model.NewScaledNumberType(0),
+			},
+			ucapi.EVChargeStrategyTypeNoDemand,
+		},
+		{
+			model.TimeSeriesSlotType{
+				TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(0)),
+				Duration:         model.NewDurationType(2 * time.Hour),
+			},
+			ucapi.EVChargeStrategyTypeUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		timeData.TimeSeriesData[0].TimeSeriesSlot = []model.TimeSeriesSlotType{tc.slot}
+		_, fErr = rTimeFeature.UpdateData(true, model.FunctionTypeTimeSeriesListData, timeData, nil, nil)
+		assert.Nil(s.T(), fErr)
+
+		data = s.sut.ChargeStrategy(s.evEntity)
+		assert.Equal(s.T(), tc.strategy, data)
+	}
+}
+
+func (s *CemCEVCSuite) Test_EnergyDemand() {
+	_, err := s.sut.EnergyDemand(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+
+	_, err = s.sut.EnergyDemand(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	descData := &model.TimeSeriesDescriptionListDataType{
+		TimeSeriesDescriptionData: []model.TimeSeriesDescriptionDataType{
+			{
+				TimeSeriesId:   util.Ptr(model.TimeSeriesIdType(1)),
+				TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypeSingleDemand),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeTimeSeries, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeTimeSeriesDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	_, err = s.sut.EnergyDemand(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	timeData := &model.TimeSeriesListDataType{
+		TimeSeriesData: []model.TimeSeriesDataType{
+			{
+				TimeSeriesId: util.Ptr(model.TimeSeriesIdType(1)),
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeTimeSeriesListData, timeData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	_, err = s.sut.EnergyDemand(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	timeData.TimeSeriesData[0].TimeSeriesSlot = []model.TimeSeriesSlotType{
+		{
+			TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(0)),
+			TimePeriod: &model.TimePeriodType{
+				StartTime: model.NewAbsoluteOrRelativeTimeTypeFromDuration(time.Minute),
+			},
+			Duration: model.NewDurationType(2 * time.Hour),
+			Value:    model.NewScaledNumberType(5000),
+			MinValue: model.NewScaledNumberType(1000),
+			MaxValue: model.NewScaledNumberType(20000),
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeTimeSeriesListData, timeData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	demand, err := s.sut.EnergyDemand(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1000.0, demand.MinDemand)
+	assert.Equal(s.T(), 5000.0, demand.OptDemand)
+	assert.Equal(s.T(), 20000.0, demand.MaxDemand)
+	assert.Equal(s.T(), time.Minute.Seconds(), demand.DurationUntilStart)
+	assert.Equal(s.T(), (2 * time.Hour).Seconds(), demand.DurationUntilEnd)
+
+	timeData.TimeSeriesData[0].TimePeriod = &model.TimePeriodType{
+		StartTime: model.NewAbsoluteOrRelativeTimeType("PT0S"),
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeTimeSeriesListData, timeData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	demand, err = s.sut.EnergyDemand(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 0.0, demand.DurationUntilStart)
+}
+
+func (s *CemCEVCSuite) Test_TimeSlotConstraints() {
+	_, err := s.sut.TimeSlotConstraints(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+
+	_, err = s.sut.TimeSlotConstraints(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	constData := &model.TimeSeriesConstraintsListDataType{
+		TimeSeriesConstraintsData: []model.TimeSeriesConstraintsDataType{
+			{
+				TimeSeriesId:         util.Ptr(model.TimeSeriesIdType(0)),
+				SlotCountMin:         util.Ptr(model.TimeSeriesSlotCountType(1)),
+				SlotCountMax:         util.Ptr(model.TimeSeriesSlotCountType(10)),
+				SlotDurationMin:      model.NewDurationType(time.Minute),
+				SlotDurationMax:      model.NewDurationType(time.Hour),
+				SlotDurationStepSize: model.NewDurationType(time.Second),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeTimeSeries, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeTimeSeriesConstraintsListData, constData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err := s.sut.TimeSlotConstraints(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), uint(1), data.MinSlots)
+	assert.Equal(s.T(), uint(10), data.MaxSlots)
+	assert.Equal(s.T(), time.Minute, data.MinSlotDuration)
+	assert.Equal(s.T(), time.Hour, data.MaxSlotDuration)
+	assert.Equal(s.T(), time.Second, data.SlotDurationStepSize)
+}
+
+func (s *CemCEVCSuite) Test_WritePowerLimits() {
+	data := []ucapi.DurationSlotValue{}
+
+	err := s.sut.WritePowerLimits(s.mockRemoteEntity, data)
+	assert.NotNil(s.T(), err)
+
+	err = s.sut.WritePowerLimits(s.evEntity, data)
+	assert.NotNil(s.T(), err)
+
+	paramData := &model.ElectricalConnectionParameterDescriptionListDataType{
+		ElectricalConnectionParameterDescriptionData: []model.ElectricalConnectionParameterDescriptionDataType{
+			{
+				ElectricalConnectionId: util.Ptr(model.ElectricalConnectionIdType(0)),
+				ParameterId:            util.Ptr(model.ElectricalConnectionParameterIdType(0)),
+				ScopeType:              util.Ptr(model.ScopeTypeTypeACPowerTotal),
+			},
+		},
+	}
+
+	rElFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeElectricalConnection, model.RoleTypeServer)
+	_, fErr := rElFeature.UpdateData(true, model.FunctionTypeElectricalConnectionParameterDescriptionListData, paramData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	err = s.sut.WritePowerLimits(s.evEntity, data)
+	assert.NotNil(s.T(), err)
+
+	permData := &model.ElectricalConnectionPermittedValueSetListDataType{
+		ElectricalConnectionPermittedValueSetData: []model.ElectricalConnectionPermittedValueSetDataType{
+			{
+				ElectricalConnectionId: util.Ptr(model.ElectricalConnectionIdType(0)),
+				ParameterId:            util.Ptr(model.ElectricalConnectionParameterIdType(0)),
+				PermittedValueSet: []model.ScaledNumberSetType{
+					{
+						Range: []model.ScaledNumberRangeType{
+							{
+								Min: model.NewScaledNumberType(0),
+								Max: model.NewScaledNumberType(11000),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, fErr = rElFeature.UpdateData(true, model.FunctionTypeElectricalConnectionPermittedValueSetListData, permData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	err = s.sut.WritePowerLimits(s.evEntity, data)
+	assert.NotNil(s.T(), err)
+
+	constData := &model.TimeSeriesConstraintsListDataType{
+		TimeSeriesConstraintsData: []model.TimeSeriesConstraintsDataType{
+			{
+				TimeSeriesId: util.Ptr(model.TimeSeriesIdType(0)),
+				SlotCountMin: util.Ptr(model.TimeSeriesSlotCountType(1)),
+				SlotCountMax: util.Ptr(model.TimeSeriesSlotCountType(2)),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeTimeSeries, model.RoleTypeServer)
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeTimeSeriesConstraintsListData, constData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	err = s.sut.WritePowerLimits(s.evEntity, data)
+	assert.NotNil(s.T(), err)
+
+	descData := &model.TimeSeriesDescriptionListDataType{
+		TimeSeriesDescriptionData: []model.TimeSeriesDescriptionDataType{
+			{
+				TimeSeriesId:   util.Ptr(model.TimeSeriesIdType(0)),
+				TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypeConstraints),
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeTimeSeriesDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	err = s.sut.WritePowerLimits(s.evEntity, data)
+	assert.Nil(s.T(), err)
+
+	data = []ucapi.DurationSlotValue{
+		{Duration: time.Hour, Value: 11000},
+		{Duration: time.Hour, Value: 4200},
+		{Duration: time.Hour, Value: 0},
+	}
+	err = s.sut.WritePowerLimits(s.evEntity, data)
+	assert.NotNil(s.T(), err)
+
+	err = s.sut.WritePowerLimits(s.evEntity, data[:2])
+	assert.Nil(s.T(), err)
+}
+
+func (s *CemCEVCSuite) Test_IncentiveConstraints() {
+	_, err := s.sut.IncentiveConstraints(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+
+	_, err = s.sut.IncentiveConstraints(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	constData := &model.IncentiveTableConstraintsDataType{
+		IncentiveTableConstraints: []model.IncentiveTableConstraintsType{
+			{
+				IncentiveSlotConstraints: &model.TimeTableConstraintsDataType{
+					SlotCountMin: util.Ptr(model.TimeSlotCountType(1)),
+					SlotCountMax: util.Ptr(model.TimeSlotCountType(10)),
+				},
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeIncentiveTable, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeIncentiveTableConstraintsData, constData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err := s.sut.IncentiveConstraints(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), uint(1), data.MinSlots)
+	assert.Equal(s.T(), uint(10), data.MaxSlots)
+}
+
+func (s *CemCEVCSuite) Test_WriteIncentiveTableDescriptions() {
+	data := []ucapi.IncentiveTariffDescription{}
+
+	err := s.sut.WriteIncentiveTableDescriptions(s.mockRemoteEntity, data)
+	assert.NotNil(s.T(), err)
+
+	err = s.sut.WriteIncentiveTableDescriptions(s.evEntity, data)
+	assert.NotNil(s.T(), err)
+
+	descData := &model.IncentiveTableDescriptionDataType{
+		IncentiveTableDescription: []model.IncentiveTableDescriptionType{
+			{
+				TariffDescription: &model.TariffDescriptionDataType{
+					TariffId:        util.Ptr(model.TariffIdType(0)),
+					TariffWriteable: util.Ptr(true),
+					UpdateRequired:  util.Ptr(true),
+					ScopeType:       util.Ptr(model.ScopeTypeTypeSimpleIncentiveTable),
+				},
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeIncentiveTable, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeIncentiveTableDescriptionData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	err = s.sut.WriteIncentiveTableDescriptions(s.evEntity, data)
+	assert.Nil(s.T(), err)
+
+	data = []ucapi.IncentiveTariffDescription{
+		{
+			Tiers: []ucapi.IncentiveTableDescriptionTier{
+				{
+					Id:   0,
+					Type: model.TierTypeTypeFixedCost,
+					Boundaries: []ucapi.TierBoundaryDescription{
+						{
+							Id:   0,
+							Type: model.TierBoundaryTypeTypePowerBoundary,
+							Unit: model.UnitOfMeasurementTypeW,
+						},
+					},
+					Incentives: []ucapi.IncentiveDescription{
+						{
+							Id:       0,
+							Type:     model.IncentiveTypeTypeAbsoluteCost,
+							Currency: model.CurrencyTypeEur,
+						},
+						{
+							Id:   1,
+							Type: model.IncentiveTypeTypeRenewableEnergyPercentage,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err = s.sut.WriteIncentiveTableDescriptions(s.evEntity, data)
+	assert.Nil(s.T(), err)
+}
+
+func (s *CemCEVCSuite) Test_WriteIncentives() {
+	data := []ucapi.DurationSlotValue{}
+
+	err := s.sut.WriteIncentives(s.mockRemoteEntity, data)
+	assert.NotNil(s.T(), err)
+
+	err = s.sut.WriteIncentives(s.evEntity, data)
+	assert.NotNil(s.T(), err)
+
+	constData := &model.IncentiveTableConstraintsDataType{
+		IncentiveTableConstraints: []model.IncentiveTableConstraintsType{
+			{
+				IncentiveSlotConstraints: &model.TimeTableConstraintsDataType{
+					SlotCountMin: util.Ptr(model.TimeSlotCountType(1)),
+					SlotCountMax: util.Ptr(model.TimeSlotCountType(2)),
+				},
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeIncentiveTable, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeIncentiveTableConstraintsData, constData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	err = s.sut.WriteIncentives(s.evEntity, data)
+	assert.Nil(s.T(), err)
+
+	data = []ucapi.DurationSlotValue{
+		{Duration: time.Hour, Value: 0.25},
+		{Duration: time.Hour, Value: 0.30},
+		{Duration: time.Hour, Value: 0.35},
+	}
+	err = s.sut.WriteIncentives(s.evEntity, data)
+	assert.NotNil(s.T(), err)
+
+	err = s.sut.WriteIncentives(s.evEntity, data[:2])
+	assert.Nil(s.T(), err)
+
+	constData.IncentiveTableConstraints[0].IncentiveSlotConstraints.SlotCountMin = util.Ptr(model.TimeSlotCountType(3))
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeIncentiveTableConstraintsData, constData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	err = s.sut.WriteIncentives(s.evEntity, data[:2])
+	assert.NotNil(s.T(), err)
+}
+
+func (s *CemCEVCSuite) Test_ChargePlanConstraints() {
+	_, err := s.sut.ChargePlanConstraints(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+
+	_, err = s.sut.ChargePlanConstraints(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	descData := &model.TimeSeriesDescriptionListDataType{
+		TimeSeriesDescriptionData: []model.TimeSeriesDescriptionDataType{
+			{
+				TimeSeriesId:   util.Ptr(model.TimeSeriesIdType(0)),
+				TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypeConstraints),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeTimeSeries, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeTimeSeriesDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	_, err = s.sut.ChargePlanConstraints(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	timeData := &model.TimeSeriesListDataType{
+		TimeSeriesData: []model.TimeSeriesDataType{
+			{
+				TimeSeriesId: util.Ptr(model.TimeSeriesIdType(0)),
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeTimeSeriesListData, timeData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	_, err = s.sut.ChargePlanConstraints(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	timeData.TimeSeriesData[0].TimeSeriesSlot = []model.TimeSeriesSlotType{
+		{
+			TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(0)),
+			Duration:         model.NewDurationType(time.Hour),
+			MaxValue:         model.NewScaledNumberType(4201),
+		},
+		{
+			TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(1)),
+			TimePeriod: &model.TimePeriodType{
+				StartTime: model.NewAbsoluteOrRelativeTimeTypeFromDuration(time.Hour),
+				EndTime:   model.NewAbsoluteOrRelativeTimeTypeFromDuration(3 * time.Hour),
+			},
+			MaxValue: model.NewScaledNumberType(11000),
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeTimeSeriesListData, timeData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err := s.sut.ChargePlanConstraints(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 2, len(data))
+	assert.Equal(s.T(), time.Hour, data[0].Duration)
+	assert.Equal(s.T(), 4201.0, data[0].Value)
+	assert.Equal(s.T(), 2*time.Hour, data[1].Duration)
+	assert.Equal(s.T(), 11000.0, data[1].Value)
+}
+
+func (s *CemCEVCSuite) Test_ChargePlan() {
+	_, err := s.sut.ChargePlan(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+
+	_, err = s.sut.ChargePlan(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	descData := &model.TimeSeriesDescriptionListDataType{
+		TimeSeriesDescriptionData: []model.TimeSeriesDescriptionDataType{
+			{
+				TimeSeriesId:   util.Ptr(model.TimeSeriesIdType(0)),
+				TimeSeriesType: util.Ptr(model.TimeSeriesTypeTypePlan),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeTimeSeries, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeTimeSeriesDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	_, err = s.sut.ChargePlan(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	timeData := &model.TimeSeriesListDataType{
+		TimeSeriesData: []model.TimeSeriesDataType{
+			{
+				TimeSeriesId: util.Ptr(model.TimeSeriesIdType(0)),
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeTimeSeriesListData, timeData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	_, err = s.sut.ChargePlan(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	timeData.TimeSeriesData[0].TimePeriod = &model.TimePeriodType{
+		StartTime: model.NewAbsoluteOrRelativeTimeType("PT0S"),
+	}
+	timeData.TimeSeriesData[0].TimeSeriesSlot = []model.TimeSeriesSlotType{
+		{
+			TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(0)),
+			Duration:         model.NewDurationType(time.Hour),
+			Value:            model.NewScaledNumberType(5000),
+			MinValue:         model.NewScaledNumberType(0),
+			MaxValue:         model.NewScaledNumberType(11000),
+		},
+		{
+			TimeSeriesSlotId: util.Ptr(model.TimeSeriesSlotIdType(1)),
+			TimePeriod: &model.TimePeriodType{
+				StartTime: model.NewAbsoluteOrRelativeTimeTypeFromDuration(time.Hour),
+				EndTime:   model.NewAbsoluteOrRelativeTimeTypeFromDuration(3 * time.Hour),
+			},
+			Value: model.NewScaledNumberType(4200),
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeTimeSeriesListData, timeData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err := s.sut.ChargePlan(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 2, len(data.Slots))
+	assert.Equal(s.T(), time.Hour, data.Slots[0].End.Sub(data.Slots[0].Start))
+	assert.Equal(s.T(), 5000.0, data.Slots[0].Value)
+	assert.Equal(s.T(), 11000.0, data.Slots[0].MaxValue)
+	assert.Equal(s.T(), data.Slots[0].End, data.Slots[1].Start)
+	assert.Equal(s.T(), 2*time.Hour, data.Slots[1].End.Sub(data.Slots[1].Start))
+	assert.Equal(s.T(), 4200.0, data.Slots[1].Value)
+}
+
+func (s *CemCEVCSuite) Test_Heartbeat() {
+	s.sut.StopHeartbeat()
+	s.sut.StartHeartbeat()
+}
+
+func (s *CemCEVCSuite) Test_SetOperatingState() {
+	err := s.sut.SetOperatingState(true)
+	assert.Nil(s.T(), err)
+
+	err = s.sut.SetOperatingState(false)
+	assert.Nil(s.T(), err)
+}
@@ -0,0 +1,57 @@
+package cevc
+
+import "github.com/enbility/eebus-go/api"
+
+const (
+	// Update of the list of remote entities supporting the Use Case
+	//
+	// Use `RemoteEntities` to get the current data
+	UseCaseSupportUpdate api.EventType = "cem-cevc-UseCaseSupportUpdate"
+
+	// EV provided an energy demand
+	//
+	// Use `EnergyDemand` to get the current data
+	//
+	// Use Case CEVC, Scenario 1
+	DataUpdateEnergyDemand api.EventType = "cem-cevc-DataUpdateEnergyDemand"
+
+	// EV provided time slot constraints
+	//
+	// Use `TimeSlotConstraints` to get the current data
+	//
+	// Use Case CEVC, Scenario 2
+	DataUpdateTimeSlotConstraints api.EventType = "cem-cevc-DataUpdateTimeSlotConstraints"
+
+	// EV requested power limits and incentives, calls to `WritePowerLimits`
+	// and `WriteIncentives` are required
+	//
+	// Use Case CEVC, Scenario 2 & 3
+	DataRequestedPowerLimitsAndIncentives api.EventType = "cem-cevc-DataRequestedPowerLimitsAndIncentives"
+
+	// EV incentive table constraints or data updated
+	//
+	// Use `IncentiveConstraints` to get the current data
+	//
+	// Use Case CEVC, Scenario 3
+	DataUpdateIncentiveTable api.EventType = "cem-cevc-DataUpdateIncentiveTable"
+
+	// EV requested an incentive table description, call to
+	// `WriteIncentiveTableDescriptions` is required
+	//
+	// Use Case CEVC, Scenario 3
+	DataRequestedIncentiveTableDescription api.EventType = "cem-cevc-DataRequestedIncentiveTableDescription"
+
+	// EV provided charge plan constraints
+	//
+	// Use `ChargePlanConstraints` to get the current data
+	//
+	// Use Case CEVC, Scenario 4
+	DataUpdateChargePlanConstraints api.EventType = "cem-cevc-DataUpdateChargePlanConstraints"
+
+	// EV provided a charge plan
+	//
+	// Use `ChargePlan` to get the current data
+	//
+	// Use Case CEVC, Scenario 4
+	DataUpdateChargePlan api.EventType = "cem-cevc-DataUpdateChargePlan"
+)
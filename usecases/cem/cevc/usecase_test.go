@@ -0,0 +1,5 @@
+package cevc
+
+func (s *CemCEVCSuite) Test_UpdateUseCaseAvailability() {
+	s.sut.UpdateUseCaseAvailability(true)
+}
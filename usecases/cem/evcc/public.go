@@ -0,0 +1,261 @@
+package evcc
+
+import (
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/features/client"
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/enbility/eebus-go/usecases/internal"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+)
+
+// return the current charge state of the EV
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such data is (yet) available
+//   - and others
+func (e *EVCC) ChargeState(entity spineapi.EntityRemoteInterface) (ucapi.EVChargeStateType, error) {
+	if entity == nil || !e.IsCompatibleEntityType(entity) {
+		return ucapi.EVChargeStateTypeUnplugged, api.ErrNoCompatibleEntity
+	}
+
+	evDeviceDiagnosis, err := client.NewDeviceDiagnosis(e.LocalEntity, entity)
+	if err != nil {
+		return ucapi.EVChargeStateTypeUnknown, err
+	}
+
+	diagnosisState, err := evDeviceDiagnosis.GetState()
+	if err != nil {
+		return ucapi.EVChargeStateTypeUnknown, err
+	}
+
+	operatingState := diagnosisState.OperatingState
+	if operatingState == nil {
+		return ucapi.EVChargeStateTypeUnknown, api.ErrDataNotAvailable
+	}
+
+	switch *operatingState {
+	case model.DeviceDiagnosisOperatingStateTypeNormalOperation:
+		return ucapi.EVChargeStateTypeActive, nil
+	case model.DeviceDiagnosisOperatingStateTypeStandby:
+		return ucapi.EVChargeStateTypePaused, nil
+	case model.DeviceDiagnosisOperatingStateTypeFailure:
+		return ucapi.EVChargeStateTypeError, nil
+	case model.DeviceDiagnosisOperatingStateTypeFinished:
+		return ucapi.EVChargeStateTypeFinished, nil
+	}
+
+	return ucapi.EVChargeStateTypeUnknown, nil
+}
+
+// return if the EV is connected
+func (e *EVCC) EVConnected(entity spineapi.EntityRemoteInterface) bool {
+	if entity == nil || !e.IsCompatibleEntityType(entity) {
+		return false
+	}
+
+	remoteDevice := e.LocalEntity.Device().RemoteDeviceForSki(entity.Device().Ski())
+	if remoteDevice == nil {
+		return false
+	}
+
+	// check if the provided entity is still part of the remote device
+	for _, item := range remoteDevice.Entities() {
+		if entity.Address().String() == item.Address().String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// return the current communication standard type used to communicate between EVSE and EV
+//
+// if an EV is connected via IEC61851, no ISO15118 specific data can be provided!
+// sometimes the connection starts with IEC61851 before it switches
+// to ISO15118, and sometimes it falls back again. so the error return is
+// never absolut for the whole connection time, except if the use case
+// is not supported
+//
+// the values are not constant and can change due to communication problems, bugs, and
+// sometimes communication starts with IEC61851 before it switches to ISO
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such data is (yet) available
+//   - and others
+func (e *EVCC) CommunicationStandard(entity spineapi.EntityRemoteInterface) (model.DeviceConfigurationKeyValueStringType, error) {
+	unknown := model.DeviceConfigurationKeyValueStringType("")
+
+	if !e.IsCompatibleEntityType(entity) {
+		return unknown, api.ErrNoCompatibleEntity
+	}
+
+	evDeviceConfiguration, err := client.NewDeviceConfiguration(e.LocalEntity, entity)
+	if err != nil {
+		return unknown, err
+	}
+
+	filter := model.DeviceConfigurationKeyValueDescriptionDataType{
+		KeyName: util.Ptr(model.DeviceConfigurationKeyNameTypeCommunicationsStandard),
+	}
+	if _, err := evDeviceConfiguration.GetKeyValueDescriptionsForFilter(filter); err != nil {
+		return unknown, err
+	}
+
+	filter.ValueType = util.Ptr(model.DeviceConfigurationKeyValueTypeTypeString)
+	data, err := evDeviceConfiguration.GetKeyValueDataForFilter(filter)
+	if err != nil || data == nil || data.Value == nil || data.Value.String == nil {
+		return unknown, api.ErrDataNotAvailable
+	}
+
+	return *data.Value.String, nil
+}
+
+// return if the EV supports asymmetric charging
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such data is (yet) available
+//   - and others
+func (e *EVCC) AsymmetricChargingSupport(entity spineapi.EntityRemoteInterface) (bool, error) {
+	if !e.IsCompatibleEntityType(entity) {
+		return false, api.ErrNoCompatibleEntity
+	}
+
+	evDeviceConfiguration, err := client.NewDeviceConfiguration(e.LocalEntity, entity)
+	if err != nil {
+		return false, err
+	}
+
+	filter := model.DeviceConfigurationKeyValueDescriptionDataType{
+		KeyName: util.Ptr(model.DeviceConfigurationKeyNameTypeAsymmetricChargingSupported),
+	}
+	if _, err := evDeviceConfiguration.GetKeyValueDescriptionsForFilter(filter); err != nil {
+		return false, err
+	}
+
+	filter.ValueType = util.Ptr(model.DeviceConfigurationKeyValueTypeTypeBoolean)
+	data, err := evDeviceConfiguration.GetKeyValueDataForFilter(filter)
+	if err != nil || data == nil || data.Value == nil || data.Value.Boolean == nil {
+		return false, api.ErrDataNotAvailable
+	}
+
+	return *data.Value.Boolean, nil
+}
+
+// return the identifications of the currently connected EV or nil if not available
+// these can be multiple, e.g. PCID, Mac Address, RFID
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such data is (yet) available
+//   - and others
+func (e *EVCC) Identifications(entity spineapi.EntityRemoteInterface) ([]ucapi.IdentificationItem, error) {
+	if !e.IsCompatibleEntityType(entity) {
+		return nil, api.ErrNoCompatibleEntity
+	}
+
+	evIdentification, err := client.NewIdentification(e.LocalEntity, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	identifications, err := evIdentification.GetDataForFilter(model.IdentificationDataType{})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []ucapi.IdentificationItem
+	for _, identification := range identifications {
+		// check if the identification Value is not empty
+		if identification.IdentificationValue == nil ||
+			len(*identification.IdentificationValue) == 0 {
+			continue
+		}
+
+		newItem := ucapi.IdentificationItem{
+			Value: string(*identification.IdentificationValue),
+		}
+		if identification.IdentificationType != nil {
+			newItem.ValueType = *identification.IdentificationType
+		}
+
+		ids = append(ids, newItem)
+	}
+
+	return ids, nil
+}
+
+// the manufacturer data of an EV
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such data is (yet) available
+//   - and others
+func (e *EVCC) ManufacturerData(entity spineapi.EntityRemoteInterface) (ucapi.ManufacturerData, error) {
+	if !e.IsCompatibleEntityType(entity) {
+		return ucapi.ManufacturerData{}, api.ErrNoCompatibleEntity
+	}
+
+	return internal.ManufacturerData(e.LocalEntity, entity)
+}
+
+// return the minimum, maximum charging and, standby power of the connected EV
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such data is (yet) available
+//   - and others
+func (e *EVCC) ChargingPowerLimits(entity spineapi.EntityRemoteInterface) (float64, float64, float64, error) {
+	if !e.IsCompatibleEntityType(entity) {
+		return 0.0, 0.0, 0.0, api.ErrNoCompatibleEntity
+	}
+
+	evElectricalConnection, err := client.NewElectricalConnection(e.LocalEntity, entity)
+	if err != nil {
+		return 0.0, 0.0, 0.0, err
+	}
+
+	filter := model.ElectricalConnectionParameterDescriptionDataType{
+		ScopeType: util.Ptr(model.ScopeTypeTypeACPowerTotal),
+	}
+	elParamDesc, err := evElectricalConnection.GetParameterDescriptionsForFilter(filter)
+	if err != nil || len(elParamDesc) == 0 || elParamDesc[0].ParameterId == nil {
+		return 0.0, 0.0, 0.0, api.ErrDataNotAvailable
+	}
+
+	valueFilter := model.ElectricalConnectionPermittedValueSetDataType{
+		ParameterId: elParamDesc[0].ParameterId,
+	}
+	minValue, maxValue, standByValue, err := evElectricalConnection.GetPermittedValueDataForFilter(valueFilter)
+	if err != nil {
+		return 0.0, 0.0, 0.0, err
+	}
+
+	return minValue, maxValue, standByValue, nil
+}
+
+// is the EV in sleep mode
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such data is (yet) available
+//   - and others
+func (e *EVCC) IsInSleepMode(entity spineapi.EntityRemoteInterface) (bool, error) {
+	if !e.IsCompatibleEntityType(entity) {
+		return false, api.ErrNoCompatibleEntity
+	}
+
+	evDeviceDiagnosis, err := client.NewDeviceDiagnosis(e.LocalEntity, entity)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := evDeviceDiagnosis.GetState()
+	if err != nil {
+		return false, err
+	}
+
+	if data.OperatingState != nil &&
+		*data.OperatingState == model.DeviceDiagnosisOperatingStateTypeStandby {
+		return true, nil
+	}
+
+	return false, nil
+}
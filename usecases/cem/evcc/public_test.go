@@ -0,0 +1,299 @@
+package evcc
+
+import (
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *CemEVCCSuite) Test_ChargeState() {
+	data, err := s.sut.ChargeState(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), ucapi.EVChargeStateTypeUnplugged, data)
+
+	data, err = s.sut.ChargeState(s.evEntity)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), ucapi.EVChargeStateTypeUnknown, data)
+
+	stateData := &model.DeviceDiagnosisStateDataType{}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeDeviceDiagnosis, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeDeviceDiagnosisStateData, stateData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err = s.sut.ChargeState(s.evEntity)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), ucapi.EVChargeStateTypeUnknown, data)
+
+	tests := []struct {
+		operatingState model.DeviceDiagnosisOperatingStateType
+		chargeState    ucapi.EVChargeStateType
+	}{
+		{model.DeviceDiagnosisOperatingStateTypeNormalOperation, ucapi.EVChargeStateTypeActive},
+		{model.DeviceDiagnosisOperatingStateTypeStandby, ucapi.EVChargeStateTypePaused},
+		{model.DeviceDiagnosisOperatingStateTypeFailure, ucapi.EVChargeStateTypeError},
+		{model.DeviceDiagnosisOperatingStateTypeFinished, ucapi.EVChargeStateTypeFinished},
+		{model.DeviceDiagnosisOperatingStateTypeTemporarilyNotReady, ucapi.EVChargeStateTypeUnknown},
+	}
+
+	for _, tc := range tests {
+		stateData.OperatingState = util.Ptr(tc.operatingState)
+		_, fErr = rFeature.UpdateData(true, model.FunctionTypeDeviceDiagnosisStateData, stateData, nil, nil)
+		assert.Nil(s.T(), fErr)
+
+		data, err = s.sut.ChargeState(s.evEntity)
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), tc.chargeState, data)
+	}
+}
+
+func (s *CemEVCCSuite) Test_EVConnected() {
+	data := s.sut.EVConnected(nil)
+	assert.Equal(s.T(), false, data)
+
+	data = s.sut.EVConnected(s.mockRemoteEntity)
+	assert.Equal(s.T(), false, data)
+
+	data = s.sut.EVConnected(s.evEntity)
+	assert.Equal(s.T(), true, data)
+}
+
+func (s *CemEVCCSuite) Test_CommunicationStandard() {
+	data, err := s.sut.CommunicationStandard(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), model.DeviceConfigurationKeyValueStringType(""), data)
+
+	data, err = s.sut.CommunicationStandard(s.evEntity)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), model.DeviceConfigurationKeyValueStringType(""), data)
+
+	descData := &model.DeviceConfigurationKeyValueDescriptionListDataType{
+		DeviceConfigurationKeyValueDescriptionData: []model.DeviceConfigurationKeyValueDescriptionDataType{
+			{
+				KeyId:     util.Ptr(model.DeviceConfigurationKeyIdType(0)),
+				KeyName:   util.Ptr(model.DeviceConfigurationKeyNameTypeCommunicationsStandard),
+				ValueType: util.Ptr(model.DeviceConfigurationKeyValueTypeTypeString),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeDeviceConfiguration, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeDeviceConfigurationKeyValueDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err = s.sut.CommunicationStandard(s.evEntity)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), model.DeviceConfigurationKeyValueStringType(""), data)
+
+	devData := &model.DeviceConfigurationKeyValueListDataType{
+		DeviceConfigurationKeyValueData: []model.DeviceConfigurationKeyValueDataType{
+			{
+				KeyId: util.Ptr(model.DeviceConfigurationKeyIdType(0)),
+				Value: &model.DeviceConfigurationKeyValueValueType{
+					String: util.Ptr(model.DeviceConfigurationKeyValueStringTypeISO151182ED1),
+				},
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeDeviceConfigurationKeyValueListData, devData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err = s.sut.CommunicationStandard(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), model.DeviceConfigurationKeyValueStringTypeISO151182ED1, data)
+}
+
+func (s *CemEVCCSuite) Test_AsymmetricChargingSupport() {
+	data, err := s.sut.AsymmetricChargingSupport(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), false, data)
+
+	data, err = s.sut.AsymmetricChargingSupport(s.evEntity)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), false, data)
+
+	descData := &model.DeviceConfigurationKeyValueDescriptionListDataType{
+		DeviceConfigurationKeyValueDescriptionData: []model.DeviceConfigurationKeyValueDescriptionDataType{
+			{
+				KeyId:     util.Ptr(model.DeviceConfigurationKeyIdType(0)),
+				KeyName:   util.Ptr(model.DeviceConfigurationKeyNameTypeAsymmetricChargingSupported),
+				ValueType: util.Ptr(model.DeviceConfigurationKeyValueTypeTypeBoolean),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeDeviceConfiguration, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeDeviceConfigurationKeyValueDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err = s.sut.AsymmetricChargingSupport(s.evEntity)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), false, data)
+
+	devData := &model.DeviceConfigurationKeyValueListDataType{
+		DeviceConfigurationKeyValueData: []model.DeviceConfigurationKeyValueDataType{
+			{
+				KeyId: util.Ptr(model.DeviceConfigurationKeyIdType(0)),
+				Value: &model.DeviceConfigurationKeyValueValueType{
+					Boolean: util.Ptr(true),
+				},
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeDeviceConfigurationKeyValueListData, devData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err = s.sut.AsymmetricChargingSupport(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), true, data)
+}
+
+func (s *CemEVCCSuite) Test_Identifications() {
+	data, err := s.sut.Identifications(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), data)
+
+	data, err = s.sut.Identifications(s.evEntity)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), data)
+
+	idData := &model.IdentificationListDataType{
+		IdentificationData: []model.IdentificationDataType{
+			{
+				IdentificationId:    util.Ptr(model.IdentificationIdType(0)),
+				IdentificationType:  util.Ptr(model.IdentificationTypeTypeEui64),
+				IdentificationValue: util.Ptr(model.IdentificationValueType("test")),
+			},
+			{
+				IdentificationId:    util.Ptr(model.IdentificationIdType(1)),
+				IdentificationType:  util.Ptr(model.IdentificationTypeTypeEui48),
+				IdentificationValue: util.Ptr(model.IdentificationValueType("")),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeIdentification, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeIdentificationListData, idData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err = s.sut.Identifications(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, len(data))
+	assert.Equal(s.T(), "test", data[0].Value)
+	assert.Equal(s.T(), model.IdentificationTypeTypeEui64, data[0].ValueType)
+}
+
+func (s *CemEVCCSuite) Test_ManufacturerData() {
+	_, err := s.sut.ManufacturerData(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+
+	_, err = s.sut.ManufacturerData(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	descData := &model.DeviceClassificationManufacturerDataType{
+		DeviceName:   util.Ptr(model.DeviceClassificationStringType("test")),
+		SerialNumber: util.Ptr(model.DeviceClassificationStringType("12345")),
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeDeviceClassification, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeDeviceClassificationManufacturerData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err := s.sut.ManufacturerData(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "test", data.DeviceName)
+	assert.Equal(s.T(), "12345", data.SerialNumber)
+	assert.Equal(s.T(), "", data.BrandName)
+}
+
+func (s *CemEVCCSuite) Test_ChargingPowerLimits() {
+	minData, maxData, standByData, err := s.sut.ChargingPowerLimits(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), 0.0, minData)
+	assert.Equal(s.T(), 0.0, maxData)
+	assert.Equal(s.T(), 0.0, standByData)
+
+	_, _, _, err = s.sut.ChargingPowerLimits(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	paramData := &model.ElectricalConnectionParameterDescriptionListDataType{
+		ElectricalConnectionParameterDescriptionData: []model.ElectricalConnectionParameterDescriptionDataType{
+			{
+				ElectricalConnectionId: util.Ptr(model.ElectricalConnectionIdType(0)),
+				ParameterId:            util.Ptr(model.ElectricalConnectionParameterIdType(0)),
+				ScopeType:              util.Ptr(model.ScopeTypeTypeACPowerTotal),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeElectricalConnection, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeElectricalConnectionParameterDescriptionListData, paramData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	_, _, _, err = s.sut.ChargingPowerLimits(s.evEntity)
+	assert.NotNil(s.T(), err)
+
+	permData := &model.ElectricalConnectionPermittedValueSetListDataType{
+		ElectricalConnectionPermittedValueSetData: []model.ElectricalConnectionPermittedValueSetDataType{
+			{
+				ElectricalConnectionId: util.Ptr(model.ElectricalConnectionIdType(0)),
+				ParameterId:            util.Ptr(model.ElectricalConnectionParameterIdType(0)),
+				PermittedValueSet: []model.ScaledNumberSetType{
+					{
+						Value: []model.ScaledNumberType{
+							*model.NewScaledNumberType(0.1),
+						},
+						Range: []model.ScaledNumberRangeType{
+							{
+								Min: model.NewScaledNumberType(1400),
+								Max: model.NewScaledNumberType(11000),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeElectricalConnectionPermittedValueSetListData, permData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	minData, maxData, standByData, err = s.sut.ChargingPowerLimits(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1400.0, minData)
+	assert.Equal(s.T(), 11000.0, maxData)
+	assert.Equal(s.T(), 0.1, standByData)
+}
+
+func (s *CemEVCCSuite) Test_IsInSleepMode() {
+	data, err := s.sut.IsInSleepMode(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), false, data)
+
+	data, err = s.sut.IsInSleepMode(s.evEntity)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), false, data)
+
+	stateData := &model.DeviceDiagnosisStateDataType{
+		OperatingState: util.Ptr(model.DeviceDiagnosisOperatingStateTypeNormalOperation),
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeDeviceDiagnosis, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeDeviceDiagnosisStateData, stateData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err = s.sut.IsInSleepMode(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), false, data)
+
+	stateData.OperatingState = util.Ptr(model.DeviceDiagnosisOperatingStateTypeStandby)
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeDeviceDiagnosisStateData, stateData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	data, err = s.sut.IsInSleepMode(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), true, data)
+}
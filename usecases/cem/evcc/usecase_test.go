@@ -0,0 +1,5 @@
+package evcc
+
+func (s *CemEVCCSuite) Test_UpdateUseCaseAvailability() {
+	s.sut.UpdateUseCaseAvailability(true)
+}
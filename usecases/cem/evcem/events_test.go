@@ -0,0 +1,124 @@
+package evcem
+
+import (
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *CemEVCEMSuite) Test_Events() {
+	payload := spineapi.EventPayload{
+		Entity: s.mockRemoteEntity,
+	}
+	s.sut.HandleEvent(payload)
+
+	payload.Entity = s.evEntity
+	s.sut.HandleEvent(payload)
+
+	payload.EventType = spineapi.EventTypeEntityChange
+	payload.ChangeType = spineapi.ElementChangeAdd
+	s.sut.HandleEvent(payload)
+
+	payload.EventType = spineapi.EventTypeDataChange
+	payload.ChangeType = spineapi.ElementChangeUpdate
+	payload.Data = util.Ptr(model.ElectricalConnectionDescriptionListDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.MeasurementDescriptionListDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.MeasurementListDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.NodeManagementUseCaseDataType{})
+	s.sut.HandleEvent(payload)
+}
+
+func (s *CemEVCEMSuite) Test_Failures() {
+	s.sut.evConnected(s.mockRemoteEntity)
+
+	s.sut.evMeasurementDescriptionDataUpdate(s.mockRemoteEntity)
+}
+
+func (s *CemEVCEMSuite) Test_evElectricalConnectionDescriptionDataUpdate() {
+	payload := spineapi.EventPayload{
+		Ski:    remoteSki,
+		Device: s.remoteDevice,
+		Entity: s.evEntity,
+	}
+	s.sut.evElectricalConnectionDescriptionDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	descData := &model.ElectricalConnectionDescriptionListDataType{
+		ElectricalConnectionDescriptionData: []model.ElectricalConnectionDescriptionDataType{
+			{
+				ElectricalConnectionId: util.Ptr(model.ElectricalConnectionIdType(0)),
+				AcConnectedPhases:      util.Ptr(uint(1)),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeElectricalConnection, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeElectricalConnectionDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evElectricalConnectionDescriptionDataUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+}
+
+func (s *CemEVCEMSuite) Test_evMeasurementDataUpdate() {
+	payload := spineapi.EventPayload{
+		Ski:    remoteSki,
+		Device: s.remoteDevice,
+		Entity: s.evEntity,
+	}
+	s.sut.evMeasurementDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	descData := &model.MeasurementDescriptionListDataType{
+		MeasurementDescriptionData: []model.MeasurementDescriptionDataType{
+			{
+				MeasurementId: util.Ptr(model.MeasurementIdType(0)),
+				ScopeType:     util.Ptr(model.ScopeTypeTypeACCurrent),
+			},
+			{
+				MeasurementId: util.Ptr(model.MeasurementIdType(1)),
+				ScopeType:     util.Ptr(model.ScopeTypeTypeACPower),
+			},
+			{
+				MeasurementId: util.Ptr(model.MeasurementIdType(2)),
+				ScopeType:     util.Ptr(model.ScopeTypeTypeCharge),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeMeasurement, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeMeasurementDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evMeasurementDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	data := &model.MeasurementListDataType{
+		MeasurementData: []model.MeasurementDataType{
+			{
+				MeasurementId: util.Ptr(model.MeasurementIdType(0)),
+				Value:         model.NewScaledNumberType(10),
+			},
+			{
+				MeasurementId: util.Ptr(model.MeasurementIdType(1)),
+				Value:         model.NewScaledNumberType(10),
+			},
+			{
+				MeasurementId: util.Ptr(model.MeasurementIdType(2)),
+				Value:         model.NewScaledNumberType(10),
+			},
+		},
+	}
+
+	payload.Data = data
+
+	s.sut.evMeasurementDataUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+}
@@ -0,0 +1,113 @@
+package evcem
+
+import (
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/features/client"
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	internal "github.com/enbility/eebus-go/usecases/internal"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+)
+
+// return the number of ac connected phases of the EV or 0 if it is unknown
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such value is (yet) available
+//   - and others
+func (e *EVCEM) PhasesConnected(entity spineapi.EntityRemoteInterface) (uint, error) {
+	if !e.IsCompatibleEntityType(entity) {
+		return 0, api.ErrNoCompatibleEntity
+	}
+
+	evElectricalConnection, err := client.NewElectricalConnection(e.LocalEntity, entity)
+	if err != nil || evElectricalConnection == nil {
+		return 0, err
+	}
+
+	data, err := evElectricalConnection.GetDescriptionsForFilter(model.ElectricalConnectionDescriptionDataType{})
+	if err != nil || len(data) == 0 || data[0].AcConnectedPhases == nil {
+		return 0, api.ErrDataNotAvailable
+	}
+
+	return *data[0].AcConnectedPhases, nil
+}
+
+// Scenario 1
+
+// return the last current measurement for each phase of the connected EV
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such value is (yet) available
+//   - ErrDataInvalid if the currently available data is invalid and should be ignored
+//   - and others
+func (e *EVCEM) CurrentPerPhase(entity spineapi.EntityRemoteInterface) ([]float64, error) {
+	if !e.IsCompatibleEntityType(entity) {
+		return nil, api.ErrNoCompatibleEntity
+	}
+
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypeCurrent),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeACCurrent),
+	}
+	return internal.MeasurementPhaseSpecificDataForFilter(e.LocalEntity, entity, filter, model.EnergyDirectionTypeConsume, ucapi.PhaseNameMapping)
+}
+
+// Scenario 2
+
+// return the last power measurement for each phase of the connected EV
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such value is (yet) available
+//   - ErrDataInvalid if the currently available data is invalid and should be ignored
+//   - and others
+func (e *EVCEM) PowerPerPhase(entity spineapi.EntityRemoteInterface) ([]float64, error) {
+	if !e.IsCompatibleEntityType(entity) {
+		return nil, api.ErrNoCompatibleEntity
+	}
+
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypePower),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeACPower),
+	}
+	return internal.MeasurementPhaseSpecificDataForFilter(e.LocalEntity, entity, filter, model.EnergyDirectionTypeConsume, ucapi.PhaseNameMapping)
+}
+
+// Scenario 3
+
+// return the charged energy measurement in Wh of the connected EV
+//
+// possible errors:
+//   - ErrDataNotAvailable if no such value is (yet) available
+//   - ErrDataInvalid if the currently available data is invalid and should be ignored
+//   - and others
+func (e *EVCEM) EnergyCharged(entity spineapi.EntityRemoteInterface) (float64, error) {
+	if !e.IsCompatibleEntityType(entity) {
+		return 0, api.ErrNoCompatibleEntity
+	}
+
+	measurement, err := client.NewMeasurement(e.LocalEntity, entity)
+	if err != nil || measurement == nil {
+		return 0, err
+	}
+
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypeEnergy),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeCharge),
+	}
+	result, err := measurement.GetDataForFilter(filter)
+	if err != nil || len(result) == 0 || result[0].Value == nil {
+		return 0, api.ErrDataNotAvailable
+	}
+
+	// if the value state is set and not normal, the value is not valid and should be ignored
+	// therefore we return an error
+	if result[0].ValueState != nil && *result[0].ValueState != model.MeasurementValueStateTypeNormal {
+		return 0, api.ErrDataInvalid
+	}
+
+	return result[0].Value.GetValue(), nil
+}
@@ -0,0 +1,5 @@
+package evcem
+
+func (s *CemEVCEMSuite) Test_UpdateUseCaseAvailability() {
+	s.sut.UpdateUseCaseAvailability(true)
+}
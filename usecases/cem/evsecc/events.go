@@ -0,0 +1,121 @@
+package evsecc
+
+import (
+	"github.com/enbility/eebus-go/features/client"
+	"github.com/enbility/eebus-go/usecases/internal"
+	"github.com/enbility/ship-go/logging"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// handle SPINE events
+func (e *EVSECC) HandleEvent(payload spineapi.EventPayload) {
+	// only about events from an EVSE entity or device changes for this remote device
+
+	if !e.IsCompatibleEntityType(payload.Entity) {
+		return
+	}
+
+	if internal.IsEntityAdded(payload) {
+		e.evseConnected(payload)
+		return
+	} else if internal.IsEntityRemoved(payload) {
+		e.evseDisconnected(payload)
+		return
+	}
+
+	if payload.EventType != spineapi.EventTypeDataChange ||
+		payload.ChangeType != spineapi.ElementChangeUpdate {
+		return
+	}
+
+	switch payload.Data.(type) {
+	case *model.DeviceClassificationManufacturerDataType:
+		e.evseManufacturerDataUpdate(payload)
+
+	case *model.DeviceDiagnosisStateDataType:
+		e.evseStateUpdate(payload)
+	}
+}
+
+// an EVSE was connected
+func (e *EVSECC) evseConnected(payload spineapi.EventPayload) {
+	if evseDeviceClassification, err := client.NewDeviceClassification(e.LocalEntity, payload.Entity); err == nil {
+		if _, err := evseDeviceClassification.RequestManufacturerDetails(); err != nil {
+			logging.Log().Error(err)
+		}
+	}
+
+	if evseDeviceDiagnosis, err := client.NewDeviceDiagnosis(e.LocalEntity, payload.Entity); err == nil {
+		if !evseDeviceDiagnosis.HasSubscription() {
+			if _, err := evseDeviceDiagnosis.Subscribe(); err != nil {
+				logging.Log().Error(err)
+			}
+		}
+
+		if _, err := evseDeviceDiagnosis.RequestState(); err != nil {
+			logging.Log().Error(err)
+		}
+	}
+
+	if e.EventCB != nil {
+		e.EventCB(payload.Ski, payload.Device, payload.Entity, EvseConnected)
+	}
+}
+
+// an EVSE was disconnected
+func (e *EVSECC) evseDisconnected(payload spineapi.EventPayload) {
+	e.failureMux.Lock()
+	delete(e.failureState, payload.Entity.Address().String())
+	e.failureMux.Unlock()
+
+	if e.EventCB != nil {
+		e.EventCB(payload.Ski, payload.Device, payload.Entity, EvseDisconnected)
+	}
+}
+
+// the manufacturer data of an EVSE was updated
+func (e *EVSECC) evseManufacturerDataUpdate(payload spineapi.EventPayload) {
+	if evseDeviceClassification, err := client.NewDeviceClassification(e.LocalEntity, payload.Entity); err == nil {
+		if _, err := evseDeviceClassification.GetManufacturerDetails(); err == nil && e.EventCB != nil {
+			e.EventCB(payload.Ski, payload.Device, payload.Entity, DataUpdateManufacturerData)
+		}
+	}
+}
+
+// the operating state of an EVSE was updated
+func (e *EVSECC) evseStateUpdate(payload spineapi.EventPayload) {
+	evseDeviceDiagnosis, err := client.NewDeviceDiagnosis(e.LocalEntity, payload.Entity)
+	if err != nil {
+		return
+	}
+
+	data, err := evseDeviceDiagnosis.GetState()
+	if err != nil {
+		return
+	}
+
+	if e.EventCB != nil {
+		e.EventCB(payload.Ski, payload.Device, payload.Entity, DataUpdateOperatingState)
+	}
+
+	failure := data.OperatingState != nil &&
+		*data.OperatingState == model.DeviceDiagnosisOperatingStateTypeFailure
+
+	// only report transitions into and out of the failure state
+	address := payload.Entity.Address().String()
+	e.failureMux.Lock()
+	previous := e.failureState[address]
+	e.failureState[address] = failure
+	e.failureMux.Unlock()
+
+	if failure == previous || e.EventCB == nil {
+		return
+	}
+
+	if failure {
+		e.EventCB(payload.Ski, payload.Device, payload.Entity, EvseFailureEntered)
+	} else {
+		e.EventCB(payload.Ski, payload.Device, payload.Entity, EvseFailureCleared)
+	}
+}
@@ -0,0 +1,120 @@
+package evsecc
+
+import (
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *CemEVSECCSuite) Test_Events() {
+	payload := spineapi.EventPayload{
+		Entity: s.mockRemoteEntity,
+	}
+	s.sut.HandleEvent(payload)
+
+	payload.Entity = s.evseEntity
+	s.sut.HandleEvent(payload)
+
+	payload.EventType = spineapi.EventTypeEntityChange
+	payload.ChangeType = spineapi.ElementChangeAdd
+	s.sut.HandleEvent(payload)
+	assert.Equal(s.T(), EvseConnected, s.lastEvent)
+
+	payload.ChangeType = spineapi.ElementChangeRemove
+	s.sut.HandleEvent(payload)
+	assert.Equal(s.T(), EvseDisconnected, s.lastEvent)
+
+	payload.EventType = spineapi.EventTypeDataChange
+	payload.ChangeType = spineapi.ElementChangeAdd
+	s.sut.HandleEvent(payload)
+
+	payload.ChangeType = spineapi.ElementChangeUpdate
+	payload.Data = util.Ptr(model.DeviceClassificationManufacturerDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.DeviceDiagnosisStateDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.NodeManagementUseCaseDataType{})
+	s.sut.HandleEvent(payload)
+}
+
+func (s *CemEVSECCSuite) Test_Failures() {
+	payload := spineapi.EventPayload{
+		Entity: s.mockRemoteEntity,
+	}
+	s.sut.evseConnected(payload)
+	assert.Equal(s.T(), EvseConnected, s.lastEvent)
+
+	s.eventCalled = false
+	s.sut.evseStateUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+}
+
+func (s *CemEVSECCSuite) Test_evseManufacturerDataUpdate() {
+	payload := spineapi.EventPayload{
+		Ski:    remoteSki,
+		Device: s.remoteDevice,
+		Entity: s.evseEntity,
+	}
+	s.sut.evseManufacturerDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	descData := &model.DeviceClassificationManufacturerDataType{
+		DeviceName: util.Ptr(model.DeviceClassificationStringType("test")),
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evseEntity, model.FeatureTypeTypeDeviceClassification, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeDeviceClassificationManufacturerData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evseManufacturerDataUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+	assert.Equal(s.T(), DataUpdateManufacturerData, s.lastEvent)
+}
+
+func (s *CemEVSECCSuite) Test_evseStateUpdate() {
+	payload := spineapi.EventPayload{
+		Ski:    remoteSki,
+		Device: s.remoteDevice,
+		Entity: s.evseEntity,
+	}
+	s.sut.evseStateUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	data := &model.DeviceDiagnosisStateDataType{
+		OperatingState: util.Ptr(model.DeviceDiagnosisOperatingStateTypeNormalOperation),
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evseEntity, model.FeatureTypeTypeDeviceDiagnosis, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeDeviceDiagnosisStateData, data, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evseStateUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+	assert.Equal(s.T(), DataUpdateOperatingState, s.lastEvent)
+
+	data = &model.DeviceDiagnosisStateDataType{
+		OperatingState: util.Ptr(model.DeviceDiagnosisOperatingStateTypeFailure),
+		LastErrorCode:  util.Ptr(model.LastErrorCodeType("error")),
+	}
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeDeviceDiagnosisStateData, data, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evseStateUpdate(payload)
+	assert.Equal(s.T(), EvseFailureEntered, s.lastEvent)
+
+	// staying in failure does not trigger another transition
+	s.sut.evseStateUpdate(payload)
+	assert.Equal(s.T(), DataUpdateOperatingState, s.lastEvent)
+
+	data = &model.DeviceDiagnosisStateDataType{
+		OperatingState: util.Ptr(model.DeviceDiagnosisOperatingStateTypeNormalOperation),
+	}
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeDeviceDiagnosisStateData, data, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evseStateUpdate(payload)
+	assert.Equal(s.T(), EvseFailureCleared, s.lastEvent)
+}
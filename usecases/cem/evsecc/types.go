@@ -0,0 +1,46 @@
+package evsecc
+
+import "github.com/enbility/eebus-go/api"
+
+const (
+	// Update of the list of remote entities supporting the Use Case
+	//
+	// Use `RemoteEntities` to get the current data
+	UseCaseSupportUpdate api.EventType = "cem-evsecc-UseCaseSupportUpdate"
+
+	// An EVSE was connected
+	EvseConnected api.EventType = "cem-evsecc-EvseConnected"
+
+	// An EVSE was disconnected
+	//
+	// Note: The evse entity is no longer connected to the device!
+	EvseDisconnected api.EventType = "cem-evsecc-EvseDisconnected"
+
+	// EVSE manufacturer data was updated
+	//
+	// Use `ManufacturerData` to get the current data
+	//
+	// Use Case EVSECC, Scenario 1
+	DataUpdateManufacturerData api.EventType = "cem-evsecc-DataUpdateManufacturerData"
+
+	// EVSE operation state was updated
+	//
+	// Use `OperatingState` to get the current data
+	//
+	// Use Case EVSECC, Scenario 2
+	DataUpdateOperatingState api.EventType = "cem-evsecc-DataUpdateOperatingState"
+
+	// EVSE entered the failure state
+	//
+	// Use `OperatingState` to get the current data, including the last error code
+	//
+	// Use Case EVSECC, Scenario 2
+	EvseFailureEntered api.EventType = "cem-evsecc-EvseFailureEntered"
+
+	// EVSE left the failure state
+	//
+	// Use `OperatingState` to get the current data
+	//
+	// Use Case EVSECC, Scenario 2
+	EvseFailureCleared api.EventType = "cem-evsecc-EvseFailureCleared"
+)
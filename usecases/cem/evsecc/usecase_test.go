@@ -0,0 +1,5 @@
+package evsecc
+
+func (s *CemEVSECCSuite) Test_UpdateUseCaseAvailability() {
+	s.sut.UpdateUseCaseAvailability(true)
+}
@@ -0,0 +1,122 @@
+package evsoc
+
+import (
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/spine"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *CemEVSOCSuite) Test_Events() {
+	payload := spineapi.EventPayload{
+		Entity: s.mockRemoteEntity,
+	}
+	s.sut.HandleEvent(payload)
+
+	payload.Entity = s.evEntity
+	s.sut.HandleEvent(payload)
+
+	payload.EventType = spineapi.EventTypeEntityChange
+	payload.ChangeType = spineapi.ElementChangeAdd
+	s.sut.HandleEvent(payload)
+
+	payload.EventType = spineapi.EventTypeDataChange
+	payload.ChangeType = spineapi.ElementChangeUpdate
+	payload.Data = util.Ptr(model.MeasurementDescriptionListDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.MeasurementListDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.NodeManagementUseCaseDataType{})
+	s.sut.HandleEvent(payload)
+}
+
+func (s *CemEVSOCSuite) Test_ScenarioAvailability() {
+	assert.False(s.T(), s.sut.IsScenarioAvailableAtEntity(s.evEntity, 1))
+
+	ucData := &model.NodeManagementUseCaseDataType{
+		UseCaseInformation: []model.UseCaseInformationDataType{
+			{
+				Address: &model.FeatureAddressType{
+					Device: s.evEntity.Address().Device,
+					Entity: s.evEntity.Address().Entity,
+				},
+				Actor: util.Ptr(model.UseCaseActorTypeEV),
+				UseCaseSupport: []model.UseCaseSupportType{
+					{
+						UseCaseName:      util.Ptr(model.UseCaseNameTypeEVStateOfCharge),
+						UseCaseAvailable: util.Ptr(true),
+						ScenarioSupport:  []model.UseCaseScenarioSupportType{1},
+					},
+				},
+			},
+		},
+	}
+
+	diEntity := s.remoteDevice.Entity(spine.DeviceInformationAddressEntity)
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(diEntity, model.FeatureTypeTypeNodeManagement, model.RoleTypeSpecial)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeNodeManagementUseCaseData, ucData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	payload := spineapi.EventPayload{
+		Ski:        remoteSki,
+		Device:     s.remoteDevice,
+		Entity:     diEntity,
+		EventType:  spineapi.EventTypeDataChange,
+		ChangeType: spineapi.ElementChangeUpdate,
+		Data:       ucData,
+	}
+	s.sut.UseCaseBase.HandleEvent(payload)
+
+	assert.True(s.T(), s.eventCalled)
+	assert.True(s.T(), s.sut.IsScenarioAvailableAtEntity(s.evEntity, 1))
+	assert.Equal(s.T(), []uint{1}, s.sut.AvailableScenariosForEntity(s.evEntity))
+}
+
+func (s *CemEVSOCSuite) Test_Failures() {
+	s.sut.evConnected(s.mockRemoteEntity)
+
+	s.sut.evMeasurementDescriptionDataUpdate(s.mockRemoteEntity)
+}
+
+func (s *CemEVSOCSuite) Test_evMeasurementDataUpdate() {
+	payload := spineapi.EventPayload{
+		Ski:    remoteSki,
+		Device: s.remoteDevice,
+		Entity: s.evEntity,
+	}
+	s.sut.evMeasurementDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	descData := &model.MeasurementDescriptionListDataType{
+		MeasurementDescriptionData: []model.MeasurementDescriptionDataType{
+			{
+				MeasurementId: util.Ptr(model.MeasurementIdType(0)),
+				ScopeType:     util.Ptr(model.ScopeTypeTypeStateOfCharge),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeMeasurement, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeMeasurementDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evMeasurementDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	data := &model.MeasurementListDataType{
+		MeasurementData: []model.MeasurementDataType{
+			{
+				MeasurementId: util.Ptr(model.MeasurementIdType(0)),
+				Value:         model.NewScaledNumberType(80),
+			},
+		},
+	}
+
+	payload.Data = data
+
+	s.sut.evMeasurementDataUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+}
@@ -0,0 +1,47 @@
+package evsoc
+
+import (
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/features/client"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+)
+
+// Scenario 1
+
+// return the EVscurrent state of charge of the EV or an error it is unknown
+//
+// possible errors:
+//   - ErrNoCompatibleEntity if entity is not compatible
+//   - ErrDataNotAvailable if no such value is (yet) available
+//   - ErrDataInvalid if the currently available data is invalid and should be ignored
+//   - and others
+func (e *EVSOC) StateOfCharge(entity spineapi.EntityRemoteInterface) (float64, error) {
+	if !e.IsCompatibleEntityType(entity) {
+		return 0, api.ErrNoCompatibleEntity
+	}
+
+	measurement, err := client.NewMeasurement(e.LocalEntity, entity)
+	if err != nil || measurement == nil {
+		return 0, err
+	}
+
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypePercentage),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeStateOfCharge),
+	}
+	result, err := measurement.GetDataForFilter(filter)
+	if err != nil || len(result) == 0 || result[0].Value == nil {
+		return 0, api.ErrDataNotAvailable
+	}
+
+	// if the value state is set and not normal, the value is not valid and should be ignored
+	// therefore we return an error
+	if result[0].ValueState != nil && *result[0].ValueState != model.MeasurementValueStateTypeNormal {
+		return 0, api.ErrDataInvalid
+	}
+
+	return result[0].Value.GetValue(), nil
+}
@@ -0,0 +1,5 @@
+package evsoc
+
+func (s *CemEVSOCSuite) Test_UpdateUseCaseAvailability() {
+	s.sut.UpdateUseCaseAvailability(true)
+}
@@ -0,0 +1,142 @@
+package opev
+
+import (
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *CemOPEVSuite) Test_Events() {
+	payload := spineapi.EventPayload{
+		Entity: s.mockRemoteEntity,
+	}
+	s.sut.HandleEvent(payload)
+
+	payload.Entity = s.evEntity
+	s.sut.HandleEvent(payload)
+
+	payload.EventType = spineapi.EventTypeEntityChange
+	payload.ChangeType = spineapi.ElementChangeAdd
+	s.sut.HandleEvent(payload)
+
+	payload.EventType = spineapi.EventTypeDataChange
+	payload.ChangeType = spineapi.ElementChangeUpdate
+	payload.Data = util.Ptr(model.ElectricalConnectionParameterDescriptionListDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.ElectricalConnectionPermittedValueSetListDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.LoadControlLimitDescriptionListDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.LoadControlLimitListDataType{})
+	s.sut.HandleEvent(payload)
+
+	payload.Data = util.Ptr(model.NodeManagementUseCaseDataType{})
+	s.sut.HandleEvent(payload)
+}
+
+func (s *CemOPEVSuite) Test_Failures() {
+	s.sut.evConnected(s.mockRemoteEntity)
+
+	s.sut.evElectricalParameterDescriptionUpdate(s.mockRemoteEntity)
+
+	s.sut.evLoadControlLimitDescriptionDataUpdate(s.mockRemoteEntity)
+}
+
+func (s *CemOPEVSuite) Test_evElectricalPermittedValuesUpdate() {
+	payload := spineapi.EventPayload{
+		Ski:    remoteSki,
+		Device: s.remoteDevice,
+		Entity: s.evEntity,
+	}
+	s.sut.evElectricalPermittedValuesUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	paramData := &model.ElectricalConnectionParameterDescriptionListDataType{
+		ElectricalConnectionParameterDescriptionData: []model.ElectricalConnectionParameterDescriptionDataType{
+			{
+				ElectricalConnectionId: util.Ptr(model.ElectricalConnectionIdType(0)),
+				ParameterId:            util.Ptr(model.ElectricalConnectionParameterIdType(0)),
+				MeasurementId:          util.Ptr(model.MeasurementIdType(0)),
+				AcMeasuredPhases:       util.Ptr(model.ElectricalConnectionPhaseNameTypeA),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeElectricalConnection, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeElectricalConnectionParameterDescriptionListData, paramData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evElectricalPermittedValuesUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	permData := &model.ElectricalConnectionPermittedValueSetListDataType{
+		ElectricalConnectionPermittedValueSetData: []model.ElectricalConnectionPermittedValueSetDataType{
+			{
+				ElectricalConnectionId: util.Ptr(model.ElectricalConnectionIdType(0)),
+				ParameterId:            util.Ptr(model.ElectricalConnectionParameterIdType(0)),
+				PermittedValueSet: []model.ScaledNumberSetType{
+					{
+						Range: []model.ScaledNumberRangeType{
+							{
+								Min: model.NewScaledNumberType(6),
+								Max: model.NewScaledNumberType(16),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeElectricalConnectionPermittedValueSetListData, permData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evElectricalPermittedValuesUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+}
+
+func (s *CemOPEVSuite) Test_evLoadControlLimitDataUpdate() {
+	payload := spineapi.EventPayload{
+		Ski:    remoteSki,
+		Device: s.remoteDevice,
+		Entity: s.evEntity,
+	}
+	s.sut.evLoadControlLimitDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	descData := &model.LoadControlLimitDescriptionListDataType{
+		LoadControlLimitDescriptionData: []model.LoadControlLimitDescriptionDataType{
+			{
+				LimitId:       util.Ptr(model.LoadControlLimitIdType(0)),
+				LimitType:     util.Ptr(model.LoadControlLimitTypeTypeMaxValueLimit),
+				LimitCategory: util.Ptr(model.LoadControlCategoryTypeObligation),
+				ScopeType:     util.Ptr(model.ScopeTypeTypeOverloadProtection),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeLoadControl, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeLoadControlLimitDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	s.sut.evLoadControlLimitDataUpdate(payload)
+	assert.False(s.T(), s.eventCalled)
+
+	data := &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{
+				LimitId: util.Ptr(model.LoadControlLimitIdType(0)),
+				Value:   model.NewScaledNumberType(16),
+			},
+		},
+	}
+
+	payload.Data = data
+
+	s.sut.evLoadControlLimitDataUpdate(payload)
+	assert.True(s.T(), s.eventCalled)
+}
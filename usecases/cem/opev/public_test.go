@@ -0,0 +1,222 @@
+package opev
+
+import (
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *CemOPEVSuite) Test_CurrentLimits() {
+	minData, maxData, defaultData, err := s.sut.CurrentLimits(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), minData)
+	assert.Nil(s.T(), maxData)
+	assert.Nil(s.T(), defaultData)
+
+	minData, maxData, defaultData, err = s.sut.CurrentLimits(s.evEntity)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), minData)
+	assert.Nil(s.T(), maxData)
+	assert.Nil(s.T(), defaultData)
+
+	measDescData := &model.MeasurementDescriptionListDataType{
+		MeasurementDescriptionData: []model.MeasurementDescriptionDataType{
+			{
+				MeasurementId:   util.Ptr(model.MeasurementIdType(0)),
+				MeasurementType: util.Ptr(model.MeasurementTypeTypeCurrent),
+				CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+				ScopeType:       util.Ptr(model.ScopeTypeTypeACCurrent),
+			},
+			{
+				MeasurementId:   util.Ptr(model.MeasurementIdType(1)),
+				MeasurementType: util.Ptr(model.MeasurementTypeTypeCurrent),
+				CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+				ScopeType:       util.Ptr(model.ScopeTypeTypeACCurrent),
+			},
+			{
+				MeasurementId:   util.Ptr(model.MeasurementIdType(2)),
+				MeasurementType: util.Ptr(model.MeasurementTypeTypeCurrent),
+				CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+				ScopeType:       util.Ptr(model.ScopeTypeTypeACCurrent),
+			},
+		},
+	}
+
+	rMFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeMeasurement, model.RoleTypeServer)
+	_, fErr := rMFeature.UpdateData(true, model.FunctionTypeMeasurementDescriptionListData, measDescData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	minData, maxData, defaultData, err = s.sut.CurrentLimits(s.evEntity)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), minData)
+	assert.Nil(s.T(), maxData)
+	assert.Nil(s.T(), defaultData)
+
+	paramData := &model.ElectricalConnectionParameterDescriptionListDataType{
+		ElectricalConnectionParameterDescriptionData: []model.ElectricalConnectionParameterDescriptionDataType{
+			{
+				ElectricalConnectionId: util.Ptr(model.ElectricalConnectionIdType(0)),
+				ParameterId:            util.Ptr(model.ElectricalConnectionParameterIdType(0)),
+				MeasurementId:          util.Ptr(model.MeasurementIdType(0)),
+				AcMeasuredPhases:       util.Ptr(model.ElectricalConnectionPhaseNameTypeA),
+			},
+			{
+				ElectricalConnectionId: util.Ptr(model.ElectricalConnectionIdType(0)),
+				ParameterId:            util.Ptr(model.ElectricalConnectionParameterIdType(1)),
+				MeasurementId:          util.Ptr(model.MeasurementIdType(1)),
+				AcMeasuredPhases:       util.Ptr(model.ElectricalConnectionPhaseNameTypeB),
+			},
+			{
+				ElectricalConnectionId: util.Ptr(model.ElectricalConnectionIdType(0)),
+				ParameterId:            util.Ptr(model.ElectricalConnectionParameterIdType(2)),
+				MeasurementId:          util.Ptr(model.MeasurementIdType(2)),
+				AcMeasuredPhases:       util.Ptr(model.ElectricalConnectionPhaseNameTypeC),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeElectricalConnection, model.RoleTypeServer)
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeElectricalConnectionParameterDescriptionListData, paramData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	minData, maxData, defaultData, err = s.sut.CurrentLimits(s.evEntity)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), minData)
+	assert.Nil(s.T(), maxData)
+	assert.Nil(s.T(), defaultData)
+
+	permData := &model.ElectricalConnectionPermittedValueSetListDataType{
+		ElectricalConnectionPermittedValueSetData: []model.ElectricalConnectionPermittedValueSetDataType{},
+	}
+	for id := 0; id < 3; id++ {
+		item := model.ElectricalConnectionPermittedValueSetDataType{
+			ElectricalConnectionId: util.Ptr(model.ElectricalConnectionIdType(0)),
+			ParameterId:            util.Ptr(model.ElectricalConnectionParameterIdType(id)),
+			PermittedValueSet: []model.ScaledNumberSetType{
+				{
+					Value: []model.ScaledNumberType{
+						*model.NewScaledNumberType(0.1),
+					},
+					Range: []model.ScaledNumberRangeType{
+						{
+							Min: model.NewScaledNumberType(6),
+							Max: model.NewScaledNumberType(16),
+						},
+					},
+				},
+			},
+		}
+		permData.ElectricalConnectionPermittedValueSetData = append(permData.ElectricalConnectionPermittedValueSetData, item)
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeElectricalConnectionPermittedValueSetListData, permData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	minData, maxData, defaultData, err = s.sut.CurrentLimits(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []float64{6, 6, 6}, minData)
+	assert.Equal(s.T(), []float64{16, 16, 16}, maxData)
+	assert.Equal(s.T(), []float64{0.1, 0.1, 0.1}, defaultData)
+}
+
+func (s *CemOPEVSuite) Test_LoadControlLimits() {
+	data, err := s.sut.LoadControlLimits(s.mockRemoteEntity)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), data)
+
+	data, err = s.sut.LoadControlLimits(s.evEntity)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), data)
+
+	s.setupLoadControlData()
+
+	data, err = s.sut.LoadControlLimits(s.evEntity)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, len(data))
+	assert.Equal(s.T(), model.ElectricalConnectionPhaseNameTypeA, data[0].Phase)
+	assert.Equal(s.T(), 16.0, data[0].Value)
+	assert.True(s.T(), data[0].IsActive)
+	assert.True(s.T(), data[0].IsChangeable)
+}
+
+func (s *CemOPEVSuite) Test_WriteLoadControlLimits() {
+	limits := []ucapi.LoadLimitsPhase{
+		{
+			Phase:    model.ElectricalConnectionPhaseNameTypeA,
+			IsActive: true,
+			Value:    10,
+		},
+	}
+
+	msgCounter, err := s.sut.WriteLoadControlLimits(s.mockRemoteEntity, limits, nil)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), msgCounter)
+
+	s.setupLoadControlData()
+
+	msgCounter, err = s.sut.WriteLoadControlLimits(s.evEntity, limits, func(result model.ResultDataType) {})
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), msgCounter)
+}
+
+func (s *CemOPEVSuite) Test_Heartbeat() {
+	s.sut.StopHeartbeat()
+	s.sut.StartHeartbeat()
+}
+
+func (s *CemOPEVSuite) Test_SetOperatingState() {
+	err := s.sut.SetOperatingState(true)
+	assert.Nil(s.T(), err)
+
+	err = s.sut.SetOperatingState(false)
+	assert.Nil(s.T(), err)
+}
+
+// setup a single phase obligation limit on the remote EV
+func (s *CemOPEVSuite) setupLoadControlData() {
+	descData := &model.LoadControlLimitDescriptionListDataType{
+		LoadControlLimitDescriptionData: []model.LoadControlLimitDescriptionDataType{
+			{
+				LimitId:       util.Ptr(model.LoadControlLimitIdType(0)),
+				MeasurementId: util.Ptr(model.MeasurementIdType(0)),
+				LimitType:     util.Ptr(model.LoadControlLimitTypeTypeMaxValueLimit),
+				LimitCategory: util.Ptr(model.LoadControlCategoryTypeObligation),
+				ScopeType:     util.Ptr(model.ScopeTypeTypeOverloadProtection),
+			},
+		},
+	}
+
+	rFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeLoadControl, model.RoleTypeServer)
+	_, fErr := rFeature.UpdateData(true, model.FunctionTypeLoadControlLimitDescriptionListData, descData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	limitData := &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{
+				LimitId:           util.Ptr(model.LoadControlLimitIdType(0)),
+				IsLimitChangeable: util.Ptr(true),
+				IsLimitActive:     util.Ptr(true),
+				Value:             model.NewScaledNumberType(16),
+			},
+		},
+	}
+
+	_, fErr = rFeature.UpdateData(true, model.FunctionTypeLoadControlLimitListData, limitData, nil, nil)
+	assert.Nil(s.T(), fErr)
+
+	paramData := &model.ElectricalConnectionParameterDescriptionListDataType{
+		ElectricalConnectionParameterDescriptionData: []model.ElectricalConnectionParameterDescriptionDataType{
+			{
+				ElectricalConnectionId: util.Ptr(model.ElectricalConnectionIdType(0)),
+				ParameterId:            util.Ptr(model.ElectricalConnectionParameterIdType(0)),
+				MeasurementId:          util.Ptr(model.MeasurementIdType(0)),
+				AcMeasuredPhases:       util.Ptr(model.ElectricalConnectionPhaseNameTypeA),
+			},
+		},
+	}
+
+	rElFeature := s.remoteDevice.FeatureByEntityTypeAndRole(s.evEntity, model.FeatureTypeTypeElectricalConnection, model.RoleTypeServer)
+	_, fErr = rElFeature.UpdateData(true, model.FunctionTypeElectricalConnectionParameterDescriptionListData, paramData, nil, nil)
+	assert.Nil(s.T(), fErr)
+}
@@ -0,0 +1,5 @@
+package opev
+
+func (s *CemOPEVSuite) Test_UpdateUseCaseAvailability() {
+	s.sut.UpdateUseCaseAvailability(true)
+}
@@ -0,0 +1,145 @@
+package oscev
+
+import (
+	"github.com/enbility/eebus-go/features/client"
+	"github.com/enbility/eebus-go/usecases/internal"
+	"github.com/enbility/ship-go/logging"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+)
+
+// handle SPINE events
+func (e *OSCEV) HandleEvent(payload spineapi.EventPayload) {
+	// only about events from an EV entity or device changes for this remote device
+
+	if !e.IsCompatibleEntityType(payload.Entity) {
+		return
+	}
+
+	if internal.IsEntityAdded(payload) {
+		e.evConnected(payload.Entity)
+		return
+	}
+
+	if payload.EventType != spineapi.EventTypeDataChange ||
+		payload.ChangeType != spineapi.ElementChangeUpdate {
+		return
+	}
+
+	switch payload.Data.(type) {
+	case *model.ElectricalConnectionParameterDescriptionListDataType:
+		e.evElectricalParameterDescriptionUpdate(payload.Entity)
+
+	case *model.ElectricalConnectionPermittedValueSetListDataType:
+		e.evElectricalPermittedValuesUpdate(payload)
+
+	case *model.LoadControlLimitDescriptionListDataType:
+		e.evLoadControlLimitDescriptionDataUpdate(payload.Entity)
+
+	case *model.LoadControlLimitListDataType:
+		e.evLoadControlLimitDataUpdate(payload)
+	}
+}
+
+// an EV was connected
+func (e *OSCEV) evConnected(entity spineapi.EntityRemoteInterface) {
+	// initialise features, e.g. subscriptions, descriptions
+	if evLoadControl, err := client.NewLoadControl(e.LocalEntity, entity); err == nil {
+		if !evLoadControl.HasSubscription() {
+			if _, err := evLoadControl.Subscribe(); err != nil {
+				logging.Log().Debug(err)
+			}
+		}
+
+		if !evLoadControl.HasBinding() {
+			if _, err := evLoadControl.Bind(); err != nil {
+				logging.Log().Debug(err)
+			}
+		}
+
+		// get descriptions
+		if _, err := evLoadControl.RequestLimitDescriptions(nil, nil); err != nil {
+			logging.Log().Debug(err)
+		}
+	}
+
+	if evElectricalConnection, err := client.NewElectricalConnection(e.LocalEntity, entity); err == nil {
+		if !evElectricalConnection.HasSubscription() {
+			if _, err := evElectricalConnection.Subscribe(); err != nil {
+				logging.Log().Debug(err)
+			}
+		}
+
+		// get electrical connection parameter descriptions
+		if _, err := evElectricalConnection.RequestParameterDescriptions(nil, nil); err != nil {
+			logging.Log().Debug(err)
+		}
+	}
+
+	if evMeasurement, err := client.NewMeasurement(e.LocalEntity, entity); err == nil {
+		// get measurement descriptions, required to map the current limits to the phases
+		if _, err := evMeasurement.RequestDescriptions(nil, nil); err != nil {
+			logging.Log().Debug(err)
+		}
+	}
+}
+
+// the electrical connection parameter description data of an EV was updated
+func (e *OSCEV) evElectricalParameterDescriptionUpdate(entity spineapi.EntityRemoteInterface) {
+	if evElectricalConnection, err := client.NewElectricalConnection(e.LocalEntity, entity); err == nil {
+		if _, err := evElectricalConnection.RequestPermittedValueSets(nil, nil); err != nil {
+			logging.Log().Error("Error getting electrical permitted values:", err)
+		}
+	}
+}
+
+// the electrical connection permitted value sets data of an EV was updated
+func (e *OSCEV) evElectricalPermittedValuesUpdate(payload spineapi.EventPayload) {
+	if evElectricalConnection, err := client.NewElectricalConnection(e.LocalEntity, payload.Entity); err == nil {
+		filter := model.ElectricalConnectionParameterDescriptionDataType{
+			AcMeasuredPhases: util.Ptr(model.ElectricalConnectionPhaseNameTypeA),
+		}
+		data, err := evElectricalConnection.GetParameterDescriptionsForFilter(filter)
+		if err != nil || len(data) == 0 || data[0].ParameterId == nil {
+			return
+		}
+
+		filter1 := model.ElectricalConnectionPermittedValueSetDataType{
+			ParameterId: data[0].ParameterId,
+		}
+		values, err := evElectricalConnection.GetPermittedValueSetForFilter(filter1)
+		if err != nil || len(values) == 0 {
+			return
+		}
+
+		// Scenario 1
+		if e.EventCB != nil {
+			e.EventCB(payload.Ski, payload.Device, payload.Entity, DataUpdateCurrentLimits)
+		}
+	}
+}
+
+// the load control limit description data of an EV was updated
+func (e *OSCEV) evLoadControlLimitDescriptionDataUpdate(entity spineapi.EntityRemoteInterface) {
+	if evLoadControl, err := client.NewLoadControl(e.LocalEntity, entity); err == nil {
+		// get values
+		if _, err := evLoadControl.RequestLimitData(nil, nil); err != nil {
+			logging.Log().Debug(err)
+		}
+	}
+}
+
+// the load control limit data of an EV was updated
+func (e *OSCEV) evLoadControlLimitDataUpdate(payload spineapi.EventPayload) {
+	if evLoadControl, err := client.NewLoadControl(e.LocalEntity, payload.Entity); err == nil {
+		filter := model.LoadControlLimitDescriptionDataType{
+			LimitType:     util.Ptr(model.LoadControlLimitTypeTypeMaxValueLimit),
+			LimitCategory: util.Ptr(model.LoadControlCategoryTypeRecommendation),
+			ScopeType:     util.Ptr(model.ScopeTypeTypeSelfConsumption),
+		}
+		if evLoadControl.CheckEventPayloadDataForFilter(payload.Data, filter) && e.EventCB != nil {
+			e.EventCB(payload.Ski, payload.Device, payload.Entity, DataUpdateLimit)
+		}
+	}
+}
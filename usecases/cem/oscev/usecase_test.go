@@ -0,0 +1,5 @@
+package oscev
+
+func (s *CemOSCEVSuite) Test_UpdateUseCaseAvailability() {
+	s.sut.UpdateUseCaseAvailability(true)
+}
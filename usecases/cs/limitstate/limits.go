@@ -0,0 +1,88 @@
+package limitstate
+
+import (
+	"math"
+	"time"
+
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+)
+
+// Limits provides the limit data of a Controllable System use case
+//
+// All power values are positive values in W.
+type Limits interface {
+	// return the current limit
+	Limit() (ucapi.LoadLimit, error)
+
+	// return the failsafe limit
+	FailsafeLimit() (float64, error)
+
+	// return the failsafe duration minimum
+	FailsafeDurationMinimum() (time.Duration, error)
+
+	// return the nominal maximum power
+	NominalMax() (float64, error)
+}
+
+type lpcLimits struct {
+	uc ucapi.CsLPCInterface
+}
+
+var _ Limits = (*lpcLimits)(nil)
+
+// return the limits of a Limitation of Power Consumption use case
+func LPCLimits(uc ucapi.CsLPCInterface) Limits {
+	return &lpcLimits{uc: uc}
+}
+
+func (l *lpcLimits) Limit() (ucapi.LoadLimit, error) {
+	return l.uc.ConsumptionLimit()
+}
+
+func (l *lpcLimits) FailsafeLimit() (float64, error) {
+	value, _, err := l.uc.FailsafeConsumptionActivePowerLimit()
+	return value, err
+}
+
+func (l *lpcLimits) FailsafeDurationMinimum() (time.Duration, error) {
+	duration, _, err := l.uc.FailsafeDurationMinimum()
+	return duration, err
+}
+
+func (l *lpcLimits) NominalMax() (float64, error) {
+	return l.uc.ConsumptionNominalMax()
+}
+
+type lppLimits struct {
+	uc ucapi.CsLPPInterface
+}
+
+var _ Limits = (*lppLimits)(nil)
+
+// return the limits of a Limitation of Power Production use case
+//
+// Production limits are negative in EEBUS, they are returned as positive values.
+func LPPLimits(uc ucapi.CsLPPInterface) Limits {
+	return &lppLimits{uc: uc}
+}
+
+func (l *lppLimits) Limit() (ucapi.LoadLimit, error) {
+	limit, err := l.uc.ProductionLimit()
+	limit.Value = math.Abs(limit.Value)
+	return limit, err
+}
+
+func (l *lppLimits) FailsafeLimit() (float64, error) {
+	value, _, err := l.uc.FailsafeProductionActivePowerLimit()
+	return math.Abs(value), err
+}
+
+func (l *lppLimits) FailsafeDurationMinimum() (time.Duration, error) {
+	duration, _, err := l.uc.FailsafeDurationMinimum()
+	return duration, err
+}
+
+func (l *lppLimits) NominalMax() (float64, error) {
+	value, err := l.uc.ProductionNominalMax()
+	return math.Abs(value), err
+}
@@ -0,0 +1,95 @@
+package limitstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/mocks"
+	"github.com/enbility/eebus-go/service"
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	cslpc "github.com/enbility/eebus-go/usecases/cs/lpc"
+	cslpp "github.com/enbility/eebus-go/usecases/cs/lpp"
+	shipapi "github.com/enbility/ship-go/api"
+	"github.com/enbility/ship-go/cert"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func localEntity(t *testing.T) spineapi.EntityLocalInterface {
+	cert, _ := cert.CreateCertificate("test", "test", "DE", "test")
+	configuration, _ := api.NewConfiguration(
+		"test", "test", "test", "test",
+		[]shipapi.DeviceCategoryType{shipapi.DeviceCategoryTypeEnergyManagementSystem},
+		model.DeviceTypeTypeEnergyManagementSystem,
+		[]model.EntityTypeType{model.EntityTypeTypeCEM},
+		9999, cert, time.Second*4)
+
+	serviceHandler := mocks.NewServiceReaderInterface(t)
+	serviceHandler.EXPECT().ServicePairingDetailUpdate(mock.Anything, mock.Anything).Return().Maybe()
+
+	eebusService := service.NewService(configuration, serviceHandler)
+	_ = eebusService.Setup()
+
+	return eebusService.LocalDevice().EntityForType(model.EntityTypeTypeCEM)
+}
+
+func TestLPCLimits(t *testing.T) {
+	uc := cslpc.NewLPC(localEntity(t), nil)
+	uc.AddFeatures()
+
+	_ = uc.SetConsumptionLimit(ucapi.LoadLimit{Value: 4000, IsActive: true, Duration: time.Hour})
+	_ = uc.SetFailsafeConsumptionActivePowerLimit(4200, true)
+	_ = uc.SetFailsafeDurationMinimum(2*time.Hour, true)
+	_ = uc.SetConsumptionNominalMax(32000)
+
+	sut := LPCLimits(uc)
+
+	limit, err := sut.Limit()
+	assert.Nil(t, err)
+	assert.Equal(t, 4000.0, limit.Value)
+	assert.True(t, limit.IsActive)
+
+	value, err := sut.FailsafeLimit()
+	assert.Nil(t, err)
+	assert.Equal(t, 4200.0, value)
+
+	duration, err := sut.FailsafeDurationMinimum()
+	assert.Nil(t, err)
+	assert.Equal(t, 2*time.Hour, duration)
+
+	value, err = sut.NominalMax()
+	assert.Nil(t, err)
+	assert.Equal(t, 32000.0, value)
+}
+
+func TestLPPLimits(t *testing.T) {
+	uc := cslpp.NewLPP(localEntity(t), nil)
+	uc.AddFeatures()
+
+	_ = uc.SetProductionLimit(ucapi.LoadLimit{Value: -6000, IsActive: true, Duration: time.Hour})
+	_ = uc.SetFailsafeProductionActivePowerLimit(-4200, true)
+	_ = uc.SetFailsafeDurationMinimum(3*time.Hour, true)
+	_ = uc.SetProductionNominalMax(10000)
+
+	sut := LPPLimits(uc)
+
+	limit, err := sut.Limit()
+	assert.Nil(t, err)
+	assert.Equal(t, 6000.0, limit.Value)
+	assert.True(t, limit.IsActive)
+
+	value, err := sut.FailsafeLimit()
+	assert.Nil(t, err)
+	assert.Equal(t, 4200.0, value)
+
+	duration, err := sut.FailsafeDurationMinimum()
+	assert.Nil(t, err)
+	assert.Equal(t, 3*time.Hour, duration)
+
+	value, err = sut.NominalMax()
+	assert.Nil(t, err)
+	assert.Equal(t, 10000.0, value)
+}
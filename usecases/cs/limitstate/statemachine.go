@@ -0,0 +1,248 @@
+package limitstate
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// StateMachine implements the states of a Controllable System for the
+// LPC and LPP use cases
+//
+// Transitions:
+//   - init: to unlimited/controlled or limited on the first heartbeat,
+//     to failsafe if no heartbeat is received within the heartbeat timeout
+//   - unlimited/controlled and limited: switch between each other depending on
+//     the current limit, to failsafe if the heartbeat times out
+//   - failsafe: to unlimited/controlled or limited if the heartbeat is present and
+//     a new limit was received, after the failsafe duration minimum to
+//     unlimited/controlled if connected, otherwise to unlimited/autonomous
+//   - unlimited/autonomous: to unlimited/controlled or limited on the next heartbeat
+//
+// The owner has to report heartbeats and limit updates of the use case and
+// call Tick periodically, e.g. by using Run.
+type StateMachine struct {
+	limits  Limits
+	clock   Clock
+	eventCB StateChangeCallback
+
+	startedAt        time.Time
+	state            State
+	stateSince       time.Time
+	failsafeDuration time.Duration
+
+	lastHeartbeat time.Time
+	heartbeatSeen bool
+
+	limitUpdated  time.Time
+	limitDuration time.Duration
+
+	mux sync.Mutex
+}
+
+// create a new state machine in init state
+//
+// parameters:
+//   - limits: the limit data of the use case, see LPCLimits and LPPLimits
+//   - clock: the time source, the system clock is used if nil
+//   - eventCB: invoked on every state transition, can be nil
+func NewStateMachine(limits Limits, clock Clock, eventCB StateChangeCallback) *StateMachine {
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	now := clock.Now()
+	return &StateMachine{
+		limits:     limits,
+		clock:      clock,
+		eventCB:    eventCB,
+		startedAt:  now,
+		state:      StateInit,
+		stateSince: now,
+	}
+}
+
+// return the current state
+func (s *StateMachine) State() State {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.state
+}
+
+// report a heartbeat of the energy guard
+func (s *StateMachine) Heartbeat() {
+	s.mux.Lock()
+	s.lastHeartbeat = s.clock.Now()
+	s.heartbeatSeen = true
+	s.mux.Unlock()
+
+	s.Tick()
+}
+
+// report that the energy guard wrote a new limit
+func (s *StateMachine) LimitUpdated() {
+	s.mux.Lock()
+	s.limitUpdated = s.clock.Now()
+	s.limitDuration = 0
+	if limit, err := s.limits.Limit(); err == nil {
+		s.limitDuration = limit.Duration
+	}
+	s.mux.Unlock()
+
+	s.Tick()
+}
+
+// evaluate the transitions for the current time
+func (s *StateMachine) Tick() {
+	s.mux.Lock()
+	from := s.state
+	to := s.nextState(s.clock.Now())
+	if to != from {
+		s.setState(to)
+	}
+	s.mux.Unlock()
+
+	if to != from && s.eventCB != nil {
+		s.eventCB(from, to)
+	}
+}
+
+// call Tick every second until the context is cancelled
+func (s *StateMachine) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick()
+		}
+	}
+}
+
+// return the active power limit in W that applies in the current state
+//
+// possible errors:
+//   - the error of the use case if the value is not available
+func (s *StateMachine) ActivePowerLimit() (float64, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	switch s.state {
+	case StateInit, StateFailsafe:
+		return s.limits.FailsafeLimit()
+	case StateLimited:
+		limit, err := s.limits.Limit()
+		return limit.Value, err
+	default:
+		return s.limits.NominalMax()
+	}
+}
+
+// return the time since the last heartbeat, or since startup if none was received
+func (s *StateMachine) HeartbeatAge() time.Duration {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if !s.heartbeatSeen {
+		return s.clock.Now().Sub(s.startedAt)
+	}
+	return s.clock.Now().Sub(s.lastHeartbeat)
+}
+
+// return the remaining time of the failsafe duration minimum, 0 if not in failsafe state
+func (s *StateMachine) FailsafeRemaining() time.Duration {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.state != StateFailsafe {
+		return 0
+	}
+	return max(s.failsafeDuration-s.clock.Now().Sub(s.stateSince), 0)
+}
+
+// return the remaining time of the active limit, 0 if not limited or the limit has no duration
+func (s *StateMachine) LimitRemaining() time.Duration {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.state != StateLimited || s.limitDuration == 0 {
+		return 0
+	}
+	return max(s.limitDuration-s.clock.Now().Sub(s.limitUpdated), 0)
+}
+
+// return the state following the current one
+//
+// has to be called with the lock held
+func (s *StateMachine) nextState(now time.Time) State {
+	connected := s.heartbeatSeen && now.Sub(s.lastHeartbeat) <= HeartbeatTimeout
+
+	switch s.state {
+	case StateInit:
+		if connected {
+			return s.controlledState(now)
+		}
+		if now.Sub(s.stateSince) > HeartbeatTimeout {
+			return StateFailsafe
+		}
+
+	case StateUnlimitedControlled, StateLimited:
+		if !connected {
+			return StateFailsafe
+		}
+		return s.controlledState(now)
+
+	case StateFailsafe:
+		if connected && s.limitUpdated.After(s.stateSince) {
+			return s.controlledState(now)
+		}
+		if now.Sub(s.stateSince) >= s.failsafeDuration {
+			if connected {
+				return s.controlledState(now)
+			}
+			return StateUnlimitedAutonomous
+		}
+
+	case StateUnlimitedAutonomous:
+		if connected {
+			return s.controlledState(now)
+		}
+	}
+
+	return s.state
+}
+
+// return limited if an active limit applies, otherwise unlimited/controlled
+//
+// has to be called with the lock held
+func (s *StateMachine) controlledState(now time.Time) State {
+	limit, err := s.limits.Limit()
+	if err != nil || !limit.IsActive {
+		return StateUnlimitedControlled
+	}
+
+	if s.limitDuration > 0 && now.Sub(s.limitUpdated) >= s.limitDuration {
+		return StateUnlimitedControlled
+	}
+
+	return StateLimited
+}
+
+// switch to a new state
+//
+// has to be called with the lock held
+func (s *StateMachine) setState(state State) {
+	s.state = state
+	s.stateSince = s.clock.Now()
+
+	if state == StateFailsafe {
+		s.failsafeDuration = DefaultFailsafeDuration
+		if duration, err := s.limits.FailsafeDurationMinimum(); err == nil && duration > 0 {
+			s.failsafeDuration = duration
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package limitstate
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *LimitStateSuite) Test_Init() {
+	assert.Equal(s.T(), StateInit, s.sut.State())
+
+	value, err := s.sut.ActivePowerLimit()
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 4200.0, value)
+
+	s.advance(HeartbeatTimeout)
+	assert.Equal(s.T(), StateInit, s.sut.State())
+	assert.Equal(s.T(), HeartbeatTimeout, s.sut.HeartbeatAge())
+
+	s.advance(time.Second)
+	assert.Equal(s.T(), StateFailsafe, s.sut.State())
+	assert.Equal(s.T(), []State{StateFailsafe}, s.transitions)
+}
+
+func (s *LimitStateSuite) Test_HeartbeatAge_NoHeartbeat() {
+	s.advance(HeartbeatTimeout + time.Second)
+	assert.Equal(s.T(), StateFailsafe, s.sut.State())
+
+	// the age keeps counting from the start across transitions
+	assert.Equal(s.T(), HeartbeatTimeout+time.Second, s.sut.HeartbeatAge())
+
+	s.advance(time.Minute)
+	assert.Equal(s.T(), HeartbeatTimeout+time.Second+time.Minute, s.sut.HeartbeatAge())
+}
+
+func (s *LimitStateSuite) Test_Init_Heartbeat() {
+	s.sut.Heartbeat()
+	assert.Equal(s.T(), StateUnlimitedControlled, s.sut.State())
+
+	value, err := s.sut.ActivePowerLimit()
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 10000.0, value)
+	assert.Equal(s.T(), time.Duration(0), s.sut.HeartbeatAge())
+}
+
+func (s *LimitStateSuite) Test_Init_ActiveLimit() {
+	s.writeLimit(3000, true, time.Hour)
+	assert.Equal(s.T(), StateInit, s.sut.State())
+
+	s.sut.Heartbeat()
+	assert.Equal(s.T(), StateLimited, s.sut.State())
+}
+
+func (s *LimitStateSuite) Test_Limited() {
+	s.sut.Heartbeat()
+	s.writeLimit(3000, true, time.Minute)
+	assert.Equal(s.T(), StateLimited, s.sut.State())
+
+	value, err := s.sut.ActivePowerLimit()
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 3000.0, value)
+	assert.Equal(s.T(), time.Minute, s.sut.LimitRemaining())
+
+	s.advance(30 * time.Second)
+	assert.Equal(s.T(), StateLimited, s.sut.State())
+	assert.Equal(s.T(), 30*time.Second, s.sut.LimitRemaining())
+
+	// the limit duration expires
+	s.advance(30 * time.Second)
+	assert.Equal(s.T(), StateUnlimitedControlled, s.sut.State())
+	assert.Equal(s.T(), time.Duration(0), s.sut.LimitRemaining())
+
+	assert.Equal(s.T(), []State{StateUnlimitedControlled, StateLimited, StateUnlimitedControlled}, s.transitions)
+}
+
+func (s *LimitStateSuite) Test_Limited_Deactivated() {
+	s.sut.Heartbeat()
+	s.writeLimit(3000, true, 0)
+	assert.Equal(s.T(), StateLimited, s.sut.State())
+
+	// without a duration the limit does not expire
+	for i := 0; i < 60; i++ {
+		s.advance(time.Minute)
+		s.sut.Heartbeat()
+	}
+	assert.Equal(s.T(), StateLimited, s.sut.State())
+	assert.Equal(s.T(), time.Duration(0), s.sut.LimitRemaining())
+
+	s.writeLimit(3000, false, 0)
+	assert.Equal(s.T(), StateUnlimitedControlled, s.sut.State())
+}
+
+func (s *LimitStateSuite) Test_Failsafe() {
+	s.sut.Heartbeat()
+	s.writeLimit(3000, true, 0)
+
+	s.advance(HeartbeatTimeout + time.Second)
+	assert.Equal(s.T(), StateFailsafe, s.sut.State())
+	assert.Equal(s.T(), 2*time.Hour, s.sut.FailsafeRemaining())
+
+	value, err := s.sut.ActivePowerLimit()
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 4200.0, value)
+
+	// a heartbeat alone does not end the failsafe state
+	s.advance(time.Minute)
+	s.sut.Heartbeat()
+	assert.Equal(s.T(), StateFailsafe, s.sut.State())
+	assert.Equal(s.T(), 2*time.Hour-time.Minute, s.sut.FailsafeRemaining())
+
+	// a new limit does
+	s.advance(time.Second)
+	s.writeLimit(3500, true, 0)
+	assert.Equal(s.T(), StateLimited, s.sut.State())
+	assert.Equal(s.T(), time.Duration(0), s.sut.FailsafeRemaining())
+}
+
+func (s *LimitStateSuite) Test_Failsafe_NoLimitBeforeHeartbeat() {
+	s.sut.Heartbeat()
+	s.advance(HeartbeatTimeout + time.Second)
+	assert.Equal(s.T(), StateFailsafe, s.sut.State())
+
+	// a limit without a heartbeat does not end the failsafe state
+	s.advance(time.Second)
+	s.writeLimit(3000, false, 0)
+	assert.Equal(s.T(), StateFailsafe, s.sut.State())
+
+	s.sut.Heartbeat()
+	assert.Equal(s.T(), StateUnlimitedControlled, s.sut.State())
+}
+
+func (s *LimitStateSuite) Test_Failsafe_DurationElapsed() {
+	s.sut.Heartbeat()
+	s.advance(HeartbeatTimeout + time.Second)
+	assert.Equal(s.T(), StateFailsafe, s.sut.State())
+
+	s.advance(2*time.Hour - time.Second)
+	assert.Equal(s.T(), StateFailsafe, s.sut.State())
+
+	s.advance(time.Second)
+	assert.Equal(s.T(), StateUnlimitedAutonomous, s.sut.State())
+
+	value, err := s.sut.ActivePowerLimit()
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 10000.0, value)
+
+	s.sut.Heartbeat()
+	assert.Equal(s.T(), StateUnlimitedControlled, s.sut.State())
+
+	assert.Equal(s.T(), []State{
+		StateUnlimitedControlled,
+		StateFailsafe,
+		StateUnlimitedAutonomous,
+		StateUnlimitedControlled,
+	}, s.transitions)
+}
+
+func (s *LimitStateSuite) Test_Failsafe_DurationElapsedConnected() {
+	s.sut.Heartbeat()
+	s.advance(HeartbeatTimeout + time.Second)
+	assert.Equal(s.T(), StateFailsafe, s.sut.State())
+
+	s.advance(2*time.Hour - time.Minute)
+	s.sut.Heartbeat()
+	assert.Equal(s.T(), StateFailsafe, s.sut.State())
+
+	s.advance(time.Minute)
+	assert.Equal(s.T(), StateUnlimitedControlled, s.sut.State())
+}
+
+func (s *LimitStateSuite) Test_Failsafe_DefaultDuration() {
+	s.limits.failsafeDuration = 0
+
+	s.advance(HeartbeatTimeout + time.Second)
+	assert.Equal(s.T(), StateFailsafe, s.sut.State())
+	assert.Equal(s.T(), DefaultFailsafeDuration, s.sut.FailsafeRemaining())
+}
+
+func (s *LimitStateSuite) Test_Run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		s.sut.Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+	<-done
+}
+
+func (s *LimitStateSuite) Test_NilClockAndCallback() {
+	sut := NewStateMachine(s.limits, nil, nil)
+	sut.Heartbeat()
+	assert.Equal(s.T(), StateUnlimitedControlled, sut.State())
+	assert.True(s.T(), sut.HeartbeatAge() < time.Second)
+}
+
+func (s *LimitStateSuite) Test_String() {
+	assert.Equal(s.T(), "init", StateInit.String())
+	assert.Equal(s.T(), "unlimitedControlled", StateUnlimitedControlled.String())
+	assert.Equal(s.T(), "limited", StateLimited.String())
+	assert.Equal(s.T(), "failsafe", StateFailsafe.String())
+	assert.Equal(s.T(), "unlimitedAutonomous", StateUnlimitedAutonomous.String())
+	assert.Equal(s.T(), "unknown", State(99).String())
+}
@@ -0,0 +1,119 @@
+package limitstate
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enbility/eebus-go/api"
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLimitStateSuite(t *testing.T) {
+	suite.Run(t, new(LimitStateSuite))
+}
+
+type LimitStateSuite struct {
+	suite.Suite
+
+	sut *StateMachine
+
+	clock  *testClock
+	limits *testLimits
+
+	transitions []State
+}
+
+func (s *LimitStateSuite) Event(from, to State) {
+	s.transitions = append(s.transitions, to)
+}
+
+func (s *LimitStateSuite) BeforeTest(suiteName, testName string) {
+	s.clock = &testClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	s.limits = &testLimits{
+		failsafeLimit:    4200,
+		failsafeDuration: 2 * time.Hour,
+		nominalMax:       10000,
+	}
+	s.transitions = nil
+
+	s.sut = NewStateMachine(s.limits, s.clock, s.Event)
+}
+
+// move the clock forward and evaluate the transitions
+func (s *LimitStateSuite) advance(d time.Duration) {
+	s.clock.Advance(d)
+	s.sut.Tick()
+}
+
+// write a new limit as the energy guard would do
+func (s *LimitStateSuite) writeLimit(value float64, active bool, duration time.Duration) {
+	s.limits.SetLimit(ucapi.LoadLimit{
+		Value:    value,
+		IsActive: active,
+		Duration: duration,
+	})
+	s.sut.LimitUpdated()
+}
+
+type testClock struct {
+	now time.Time
+	mux sync.Mutex
+}
+
+func (c *testClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+type testLimits struct {
+	limit            *ucapi.LoadLimit
+	failsafeLimit    float64
+	failsafeDuration time.Duration
+	nominalMax       float64
+	mux              sync.Mutex
+}
+
+var _ Limits = (*testLimits)(nil)
+
+func (l *testLimits) SetLimit(limit ucapi.LoadLimit) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.limit = &limit
+}
+
+func (l *testLimits) Limit() (ucapi.LoadLimit, error) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if l.limit == nil {
+		return ucapi.LoadLimit{}, api.ErrDataNotAvailable
+	}
+	return *l.limit, nil
+}
+
+func (l *testLimits) FailsafeLimit() (float64, error) {
+	return l.failsafeLimit, nil
+}
+
+func (l *testLimits) FailsafeDurationMinimum() (time.Duration, error) {
+	if l.failsafeDuration == 0 {
+		return 0, api.ErrDataNotAvailable
+	}
+	return l.failsafeDuration, nil
+}
+
+func (l *testLimits) NominalMax() (float64, error) {
+	return l.nominalMax, nil
+}
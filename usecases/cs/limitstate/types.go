@@ -0,0 +1,74 @@
+package limitstate
+
+import "time"
+
+// State of a Controllable System as defined in the LPC and LPP use case specifications
+type State int
+
+const (
+	// Init state after startup
+	//
+	// The failsafe limit applies until the energy guard sends a heartbeat
+	// or the heartbeat timeout elapses.
+	StateInit State = iota
+
+	// Unlimited/controlled state
+	//
+	// The energy guard is connected and no limit is active.
+	StateUnlimitedControlled
+
+	// Limited state
+	//
+	// The energy guard is connected and an active limit applies.
+	StateLimited
+
+	// Failsafe state
+	//
+	// The heartbeat of the energy guard is missing, the failsafe limit applies
+	// for at least the failsafe duration minimum.
+	StateFailsafe
+
+	// Unlimited/autonomous state
+	//
+	// The failsafe duration minimum elapsed without a connection to the energy guard.
+	StateUnlimitedAutonomous
+)
+
+func (s State) String() string {
+	switch s {
+	case StateInit:
+		return "init"
+	case StateUnlimitedControlled:
+		return "unlimitedControlled"
+	case StateLimited:
+		return "limited"
+	case StateFailsafe:
+		return "failsafe"
+	case StateUnlimitedAutonomous:
+		return "unlimitedAutonomous"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// the energy guard is considered disconnected if no heartbeat was received for this duration
+	HeartbeatTimeout = 120 * time.Second
+
+	// the failsafe duration minimum used if the use case does not provide one
+	DefaultFailsafeDuration = 2 * time.Hour
+)
+
+// Callback invoked after every state transition
+type StateChangeCallback func(from, to State)
+
+// Source of the current time, can be replaced in tests
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,139 @@
+package mgcp
+
+import (
+	"github.com/enbility/eebus-go/features/server"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+)
+
+// Scenario 1
+
+// set the current power limitation factor
+//
+// parameters:
+//   - factor: the power limitation factor in percent
+func (e *MGCP) SetPowerLimitationFactor(factor float64) error {
+	dcs, err := server.NewDeviceConfiguration(e.LocalEntity)
+	if err != nil {
+		return err
+	}
+
+	data := model.DeviceConfigurationKeyValueDataType{
+		Value: &model.DeviceConfigurationKeyValueValueType{
+			ScaledNumber: model.NewScaledNumberType(factor),
+		},
+		IsValueChangeable: util.Ptr(false),
+	}
+	filter := model.DeviceConfigurationKeyValueDescriptionDataType{
+		KeyName:   util.Ptr(model.DeviceConfigurationKeyNameTypePvCurtailmentLimitFactor),
+		ValueType: util.Ptr(model.DeviceConfigurationKeyValueTypeTypeScaledNumber),
+	}
+
+	return dcs.UpdateKeyValueDataForFilter(data, nil, filter)
+}
+
+// Scenario 2
+
+// set the momentary power consumption or production at the grid connection point
+//
+// parameters:
+//   - power: the power in W
+//
+// value semantics:
+//   - positive values are used for consumption
+//   - negative values are used for production
+func (e *MGCP) SetPower(power float64) error {
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypePower),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeACPowerTotal),
+	}
+	return e.updateMeasurementData(filter, power)
+}
+
+// Scenario 3
+
+// set the total feed in energy at the grid connection point
+//
+// parameters:
+//   - energy: the energy in Wh
+func (e *MGCP) SetEnergyFeedIn(energy float64) error {
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypeEnergy),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeGridFeedIn),
+	}
+	return e.updateMeasurementData(filter, energy)
+}
+
+// Scenario 4
+
+// set the total consumption energy at the grid connection point
+//
+// parameters:
+//   - energy: the energy in Wh
+func (e *MGCP) SetEnergyConsumed(energy float64) error {
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypeEnergy),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeGridConsumption),
+	}
+	return e.updateMeasurementData(filter, energy)
+}
+
+// Scenario 5
+
+// set the momentary current consumption or production at the grid connection point
+//
+// parameters:
+//   - currents: the currents in A, one value per phase in the order A, B, C
+//
+// value semantics:
+//   - positive values are used for consumption
+//   - negative values are used for production
+//
+// possible errors:
+//   - ErrDataInvalid if no or more than three values are provided
+//   - and others
+func (e *MGCP) SetCurrentPerPhase(currents []float64) error {
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypeCurrent),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeACCurrent),
+	}
+	return e.updatePhaseMeasurementData(filter, currents)
+}
+
+// Scenario 6
+
+// set the voltage phase details at the grid connection point
+//
+// parameters:
+//   - voltages: the voltages in V, one value per phase in the order A, B, C
+//
+// possible errors:
+//   - ErrDataInvalid if no or more than three values are provided
+//   - and others
+func (e *MGCP) SetVoltagePerPhase(voltages []float64) error {
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypeVoltage),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeACVoltage),
+	}
+	return e.updatePhaseMeasurementData(filter, voltages)
+}
+
+// Scenario 7
+
+// set the frequency at the grid connection point
+//
+// parameters:
+//   - frequency: the frequency in Hz
+func (e *MGCP) SetFrequency(frequency float64) error {
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypeFrequency),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeACFrequency),
+	}
+	return e.updateMeasurementData(filter, frequency)
+}
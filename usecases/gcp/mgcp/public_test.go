@@ -0,0 +1,109 @@
+package mgcp
+
+import (
+	"github.com/enbility/eebus-go/features/server"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *GcpMGCPSuite) measurementValues(scope model.ScopeTypeType) []float64 {
+	measurement, err := server.NewMeasurement(s.sut.LocalEntity)
+	assert.Nil(s.T(), err)
+
+	filter := model.MeasurementDescriptionDataType{
+		ScopeType: util.Ptr(scope),
+	}
+	data, err := measurement.GetDataForFilter(filter)
+	if err != nil {
+		return nil
+	}
+
+	var result []float64
+	for _, item := range data {
+		if item.Value != nil {
+			result = append(result, item.Value.GetValue())
+		}
+	}
+	return result
+}
+
+func (s *GcpMGCPSuite) Test_SetPowerLimitationFactor() {
+	err := s.sut.SetPowerLimitationFactor(70)
+	assert.Nil(s.T(), err)
+
+	dcs, err := server.NewDeviceConfiguration(s.sut.LocalEntity)
+	assert.Nil(s.T(), err)
+
+	filter := model.DeviceConfigurationKeyValueDescriptionDataType{
+		KeyName: util.Ptr(model.DeviceConfigurationKeyNameTypePvCurtailmentLimitFactor),
+	}
+	data, err := dcs.GetKeyValueDataForFilter(filter)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), data)
+	assert.Equal(s.T(), 70.0, data.Value.ScaledNumber.GetValue())
+
+	s.sut.LocalEntity = nil
+	err = s.sut.SetPowerLimitationFactor(70)
+	assert.NotNil(s.T(), err)
+}
+
+func (s *GcpMGCPSuite) Test_SetPower() {
+	data := s.measurementValues(model.ScopeTypeTypeACPowerTotal)
+	assert.Nil(s.T(), data)
+
+	err := s.sut.SetPower(-1500)
+	assert.Nil(s.T(), err)
+
+	data = s.measurementValues(model.ScopeTypeTypeACPowerTotal)
+	assert.Equal(s.T(), []float64{-1500}, data)
+}
+
+func (s *GcpMGCPSuite) Test_SetEnergyFeedIn() {
+	err := s.sut.SetEnergyFeedIn(1000)
+	assert.Nil(s.T(), err)
+
+	data := s.measurementValues(model.ScopeTypeTypeGridFeedIn)
+	assert.Equal(s.T(), []float64{1000}, data)
+}
+
+func (s *GcpMGCPSuite) Test_SetEnergyConsumed() {
+	err := s.sut.SetEnergyConsumed(2000)
+	assert.Nil(s.T(), err)
+
+	data := s.measurementValues(model.ScopeTypeTypeGridConsumption)
+	assert.Equal(s.T(), []float64{2000}, data)
+}
+
+func (s *GcpMGCPSuite) Test_SetCurrentPerPhase() {
+	err := s.sut.SetCurrentPerPhase([]float64{10, 11})
+	assert.Nil(s.T(), err)
+
+	data := s.measurementValues(model.ScopeTypeTypeACCurrent)
+	assert.Equal(s.T(), []float64{10, 11}, data)
+
+	err = s.sut.SetCurrentPerPhase([]float64{10, 11, 12})
+	assert.Nil(s.T(), err)
+
+	data = s.measurementValues(model.ScopeTypeTypeACCurrent)
+	assert.Equal(s.T(), []float64{10, 11, 12}, data)
+
+	err = s.sut.SetCurrentPerPhase(nil)
+	assert.NotNil(s.T(), err)
+}
+
+func (s *GcpMGCPSuite) Test_SetVoltagePerPhase() {
+	err := s.sut.SetVoltagePerPhase([]float64{230, 231, 232})
+	assert.Nil(s.T(), err)
+
+	data := s.measurementValues(model.ScopeTypeTypeACVoltage)
+	assert.Equal(s.T(), []float64{230, 231, 232}, data)
+}
+
+func (s *GcpMGCPSuite) Test_SetFrequency() {
+	err := s.sut.SetFrequency(50)
+	assert.Nil(s.T(), err)
+
+	data := s.measurementValues(model.ScopeTypeTypeACFrequency)
+	assert.Equal(s.T(), []float64{50}, data)
+}
@@ -0,0 +1,314 @@
+package mgcp
+
+import (
+	"slices"
+	"time"
+
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/features/server"
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/enbility/eebus-go/usecases/usecase"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/spine"
+	"github.com/enbility/spine-go/util"
+)
+
+type MGCP struct {
+	*usecase.UseCaseBase
+}
+
+var _ ucapi.GcpMGCPInterface = (*MGCP)(nil)
+
+// Add support for the Monitoring of Grid Connection Point (MGCP) use case
+// as a Grid Connection Point actor
+//
+// Parameters:
+//   - localEntity: The local entity which should support the use case
+//   - eventCB: The callback to be called when an event is triggered (optional, can be nil)
+func NewMGCP(localEntity spineapi.EntityLocalInterface, eventCB api.EntityEventCallback) *MGCP {
+	validActorTypes := []model.UseCaseActorType{model.UseCaseActorTypeMonitoringAppliance}
+	validEntityTypes := []model.EntityTypeType{model.EntityTypeTypeCEM}
+	useCaseScenarios := []api.UseCaseScenario{
+		{
+			Scenario:  model.UseCaseScenarioSupportType(1),
+			Mandatory: false,
+		},
+		{
+			Scenario:  model.UseCaseScenarioSupportType(2),
+			Mandatory: true,
+		},
+		{
+			Scenario:  model.UseCaseScenarioSupportType(3),
+			Mandatory: true,
+		},
+		{
+			Scenario:  model.UseCaseScenarioSupportType(4),
+			Mandatory: true,
+		},
+		{
+			Scenario:  model.UseCaseScenarioSupportType(5),
+			Mandatory: false,
+		},
+		{
+			Scenario:  model.UseCaseScenarioSupportType(6),
+			Mandatory: false,
+		},
+		{
+			Scenario:  model.UseCaseScenarioSupportType(7),
+			Mandatory: false,
+		},
+	}
+
+	usecase := usecase.NewUseCaseBase(
+		localEntity,
+		model.UseCaseActorTypeGridConnectionPoint,
+		model.UseCaseNameTypeMonitoringOfGridConnectionPoint,
+		"1.0.0",
+		"release",
+		useCaseScenarios,
+		eventCB,
+		UseCaseSupportUpdate,
+		validActorTypes,
+		validEntityTypes)
+
+	uc := &MGCP{
+		UseCaseBase: usecase,
+	}
+
+	_ = spine.Events.Subscribe(uc)
+
+	return uc
+}
+
+func (e *MGCP) AddFeatures() {
+	// server features
+	f := e.LocalEntity.GetOrAddFeature(model.FeatureTypeTypeDeviceConfiguration, model.RoleTypeServer)
+	f.AddFunctionType(model.FunctionTypeDeviceConfigurationKeyValueDescriptionListData, true, false)
+	f.AddFunctionType(model.FunctionTypeDeviceConfigurationKeyValueListData, true, false)
+
+	if dcs, err := server.NewDeviceConfiguration(e.LocalEntity); err == nil {
+		dcs.AddKeyValueDescription(
+			model.DeviceConfigurationKeyValueDescriptionDataType{
+				KeyName:   util.Ptr(model.DeviceConfigurationKeyNameTypePvCurtailmentLimitFactor),
+				ValueType: util.Ptr(model.DeviceConfigurationKeyValueTypeTypeScaledNumber),
+				Unit:      util.Ptr(model.UnitOfMeasurementTypepct),
+			},
+		)
+	}
+
+	f = e.LocalEntity.GetOrAddFeature(model.FeatureTypeTypeElectricalConnection, model.RoleTypeServer)
+	f.AddFunctionType(model.FunctionTypeElectricalConnectionDescriptionListData, true, false)
+	f.AddFunctionType(model.FunctionTypeElectricalConnectionParameterDescriptionListData, true, false)
+
+	f = e.LocalEntity.GetOrAddFeature(model.FeatureTypeTypeMeasurement, model.RoleTypeServer)
+	f.AddFunctionType(model.FunctionTypeMeasurementDescriptionListData, true, false)
+	f.AddFunctionType(model.FunctionTypeMeasurementListData, true, false)
+
+	ec, err := server.NewElectricalConnection(e.LocalEntity)
+	if err != nil {
+		return
+	}
+	measurement, err := server.NewMeasurement(e.LocalEntity)
+	if err != nil {
+		return
+	}
+
+	electricalConnectionId := util.Ptr(model.ElectricalConnectionIdType(0))
+
+	// only add if it doesn't exist yet
+	filter := model.ElectricalConnectionDescriptionDataType{
+		ElectricalConnectionId: electricalConnectionId,
+	}
+	if data, err := ec.GetDescriptionsForFilter(filter); err != nil || len(data) == 0 {
+		_ = ec.AddDescription(model.ElectricalConnectionDescriptionDataType{
+			ElectricalConnectionId:  electricalConnectionId,
+			PowerSupplyType:         util.Ptr(model.ElectricalConnectionVoltageTypeTypeAc),
+			PositiveEnergyDirection: util.Ptr(model.EnergyDirectionTypeConsume),
+		})
+	}
+
+	measurements := []struct {
+		description model.MeasurementDescriptionDataType
+		phases      []model.ElectricalConnectionPhaseNameType
+		reference   *model.ElectricalConnectionPhaseNameType
+		acType      *model.ElectricalConnectionAcMeasurementTypeType
+	}{
+		{
+			// Scenario 2
+			description: model.MeasurementDescriptionDataType{
+				MeasurementType: util.Ptr(model.MeasurementTypeTypePower),
+				CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+				Unit:            util.Ptr(model.UnitOfMeasurementTypeW),
+				ScopeType:       util.Ptr(model.ScopeTypeTypeACPowerTotal),
+			},
+			phases: []model.ElectricalConnectionPhaseNameType{model.ElectricalConnectionPhaseNameTypeAbc},
+			acType: util.Ptr(model.ElectricalConnectionAcMeasurementTypeTypeReal),
+		},
+		{
+			// Scenario 3
+			description: model.MeasurementDescriptionDataType{
+				MeasurementType: util.Ptr(model.MeasurementTypeTypeEnergy),
+				CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+				Unit:            util.Ptr(model.UnitOfMeasurementTypeWh),
+				ScopeType:       util.Ptr(model.ScopeTypeTypeGridFeedIn),
+			},
+			phases: []model.ElectricalConnectionPhaseNameType{model.ElectricalConnectionPhaseNameTypeAbc},
+			acType: util.Ptr(model.ElectricalConnectionAcMeasurementTypeTypeReal),
+		},
+		{
+			// Scenario 4
+			description: model.MeasurementDescriptionDataType{
+				MeasurementType: util.Ptr(model.MeasurementTypeTypeEnergy),
+				CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+				Unit:            util.Ptr(model.UnitOfMeasurementTypeWh),
+				ScopeType:       util.Ptr(model.ScopeTypeTypeGridConsumption),
+			},
+			phases: []model.ElectricalConnectionPhaseNameType{model.ElectricalConnectionPhaseNameTypeAbc},
+			acType: util.Ptr(model.ElectricalConnectionAcMeasurementTypeTypeReal),
+		},
+		{
+			// Scenario 5
+			description: model.MeasurementDescriptionDataType{
+				MeasurementType: util.Ptr(model.MeasurementTypeTypeCurrent),
+				CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+				Unit:            util.Ptr(model.UnitOfMeasurementTypeA),
+				ScopeType:       util.Ptr(model.ScopeTypeTypeACCurrent),
+			},
+			phases: ucapi.PhaseNameMapping,
+			acType: util.Ptr(model.ElectricalConnectionAcMeasurementTypeTypeReal),
+		},
+		{
+			// Scenario 6
+			description: model.MeasurementDescriptionDataType{
+				MeasurementType: util.Ptr(model.MeasurementTypeTypeVoltage),
+				CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+				Unit:            util.Ptr(model.UnitOfMeasurementTypeV),
+				ScopeType:       util.Ptr(model.ScopeTypeTypeACVoltage),
+			},
+			phases:    ucapi.PhaseNameMapping,
+			reference: util.Ptr(model.ElectricalConnectionPhaseNameTypeNeutral),
+			acType:    util.Ptr(model.ElectricalConnectionAcMeasurementTypeTypeApparent),
+		},
+		{
+			// Scenario 7
+			description: model.MeasurementDescriptionDataType{
+				MeasurementType: util.Ptr(model.MeasurementTypeTypeFrequency),
+				CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+				Unit:            util.Ptr(model.UnitOfMeasurementTypeHz),
+				ScopeType:       util.Ptr(model.ScopeTypeTypeACFrequency),
+			},
+		},
+	}
+
+	for _, item := range measurements {
+		// only add if it doesn't exist yet
+		if data, err := measurement.GetDescriptionsForFilter(item.description); err == nil && len(data) > 0 {
+			continue
+		}
+
+		if len(item.phases) == 0 {
+			_ = measurement.AddDescription(item.description)
+			continue
+		}
+
+		for _, phase := range item.phases {
+			measurementId := measurement.AddDescription(item.description)
+			if measurementId == nil {
+				continue
+			}
+
+			_ = ec.AddParameterDescription(model.ElectricalConnectionParameterDescriptionDataType{
+				ElectricalConnectionId:  electricalConnectionId,
+				MeasurementId:           measurementId,
+				VoltageType:             util.Ptr(model.ElectricalConnectionVoltageTypeTypeAc),
+				AcMeasuredPhases:        util.Ptr(phase),
+				AcMeasuredInReferenceTo: item.reference,
+				AcMeasurementType:       item.acType,
+				AcMeasurementVariant:    util.Ptr(model.ElectricalConnectionMeasurandVariantTypeRms),
+			})
+		}
+	}
+}
+
+// returns a measurement data set for a value
+func (e *MGCP) measurementData(value float64) model.MeasurementDataType {
+	return model.MeasurementDataType{
+		ValueType:   util.Ptr(model.MeasurementValueTypeTypeValue),
+		Timestamp:   model.NewAbsoluteOrRelativeTimeTypeFromTime(time.Now()),
+		Value:       model.NewScaledNumberType(value),
+		ValueSource: util.Ptr(model.MeasurementValueSourceTypeMeasuredValue),
+		ValueState:  util.Ptr(model.MeasurementValueStateTypeNormal),
+	}
+}
+
+// update the measurement data of the single measurement matching the filter
+func (e *MGCP) updateMeasurementData(filter model.MeasurementDescriptionDataType, value float64) error {
+	measurement, err := server.NewMeasurement(e.LocalEntity)
+	if err != nil {
+		return err
+	}
+
+	data := []api.MeasurementDataForFilter{
+		{
+			Data:   e.measurementData(value),
+			Filter: filter,
+		},
+	}
+
+	return measurement.UpdateDataForFilters(data, nil, nil)
+}
+
+// update the phase specific measurement data of the measurements matching the filter
+//
+// the values have to be provided in the order of phase A, B and C
+func (e *MGCP) updatePhaseMeasurementData(filter model.MeasurementDescriptionDataType, values []float64) error {
+	if len(values) == 0 || len(values) > len(ucapi.PhaseNameMapping) {
+		return api.ErrDataInvalid
+	}
+
+	measurement, err := server.NewMeasurement(e.LocalEntity)
+	if err != nil {
+		return err
+	}
+	ec, err := server.NewElectricalConnection(e.LocalEntity)
+	if err != nil {
+		return err
+	}
+
+	descriptions, err := measurement.GetDescriptionsForFilter(filter)
+	if err != nil || len(descriptions) == 0 {
+		return api.ErrMetadataNotAvailable
+	}
+
+	var data []api.MeasurementDataForID
+	for _, description := range descriptions {
+		if description.MeasurementId == nil {
+			continue
+		}
+
+		paramFilter := model.ElectricalConnectionParameterDescriptionDataType{
+			MeasurementId: description.MeasurementId,
+		}
+		params, err := ec.GetParameterDescriptionsForFilter(paramFilter)
+		if err != nil || len(params) == 0 || params[0].AcMeasuredPhases == nil {
+			continue
+		}
+
+		index := slices.Index(ucapi.PhaseNameMapping, *params[0].AcMeasuredPhases)
+		if index < 0 || index >= len(values) {
+			continue
+		}
+
+		data = append(data, api.MeasurementDataForID{
+			Data: e.measurementData(values[index]),
+			Id:   *description.MeasurementId,
+		})
+	}
+
+	if len(data) != len(values) {
+		return api.ErrMetadataNotAvailable
+	}
+
+	return measurement.UpdateDataForIds(data)
+}
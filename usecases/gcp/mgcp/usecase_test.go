@@ -0,0 +1,68 @@
+package mgcp
+
+import (
+	"github.com/enbility/eebus-go/features/server"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *GcpMGCPSuite) Test_AddFeatures() {
+	assert.NotNil(s.T(), s.measurementFeature)
+	assert.NotNil(s.T(), s.electricalConnectionFeature)
+	assert.NotNil(s.T(), s.deviceConfigurationFeature)
+
+	measurement, err := server.NewMeasurement(s.sut.LocalEntity)
+	assert.Nil(s.T(), err)
+
+	descs, err := measurement.GetDescriptionsForFilter(model.MeasurementDescriptionDataType{})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 10, len(descs))
+
+	ec, err := server.NewElectricalConnection(s.sut.LocalEntity)
+	assert.Nil(s.T(), err)
+
+	params, err := ec.GetParameterDescriptionsForFilter(model.ElectricalConnectionParameterDescriptionDataType{})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 9, len(params))
+
+	// adding the features again must not duplicate the descriptions
+	s.sut.AddFeatures()
+
+	descs, err = measurement.GetDescriptionsForFilter(model.MeasurementDescriptionDataType{})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 10, len(descs))
+}
+
+func (s *GcpMGCPSuite) Test_updatePhaseMeasurementData() {
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypeCurrent),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeACCurrent),
+	}
+
+	err := s.sut.updatePhaseMeasurementData(filter, nil)
+	assert.NotNil(s.T(), err)
+
+	err = s.sut.updatePhaseMeasurementData(filter, []float64{1, 2, 3, 4})
+	assert.NotNil(s.T(), err)
+
+	err = s.sut.updatePhaseMeasurementData(filter, []float64{1})
+	assert.Nil(s.T(), err)
+
+	filter.ScopeType = util.Ptr(model.ScopeTypeTypeACPowerTotal)
+	err = s.sut.updatePhaseMeasurementData(filter, []float64{1, 2})
+	assert.NotNil(s.T(), err)
+
+	filter.ScopeType = util.Ptr(model.ScopeTypeTypeACFrequency)
+	err = s.sut.updatePhaseMeasurementData(filter, []float64{1})
+	assert.NotNil(s.T(), err)
+
+	filter.ScopeType = util.Ptr(model.ScopeTypeTypeACPower)
+	err = s.sut.updatePhaseMeasurementData(filter, []float64{1})
+	assert.NotNil(s.T(), err)
+}
+
+func (s *GcpMGCPSuite) Test_UpdateUseCaseAvailability() {
+	s.sut.UpdateUseCaseAvailability(true)
+}
@@ -0,0 +1,59 @@
+package mpc
+
+import "github.com/enbility/eebus-go/api"
+
+const (
+	// Update of the list of remote entites supporting the Use Case
+	//
+	// Use `RemoteEntities` to get the current data
+	UseCaseSupportUpdate api.EventType = "ma-mpc-UseCaseSupportUpdate"
+
+	// Total momentary active power consumption or production
+	//
+	// Use `Power` to get the current data
+	//
+	// Use Case MPC, Scenario 1
+	DataUpdatePower api.EventType = "ma-mpc-DataUpdatePower"
+
+	// Phase specific momentary active power consumption or production
+	//
+	// Use `PowerPerPhase` to get the current data
+	//
+	// Use Case MPC, Scenario 1
+	DataUpdatePowerPerPhase api.EventType = "ma-mpc-DataUpdatePowerPerPhase"
+
+	// Total energy consumed
+	//
+	// Use `EnergyConsumed` to get the current data
+	//
+	// Use Case MPC, Scenario 2
+	DataUpdateEnergyConsumed api.EventType = "ma-mpc-DataUpdateEnergyConsumed"
+
+	// Total energy produced
+	//
+	// Use `EnergyProduced` to get the current data
+	//
+	// Use Case MPC, Scenario 2
+	DataUpdateEnergyProduced api.EventType = "ma-mpc-DataUpdateEnergyProduced"
+
+	// Phase specific momentary current consumption or production
+	//
+	// Use `CurrentPerPhase` to get the current data
+	//
+	// Use Case MPC, Scenario 3
+	DataUpdateCurrentPerPhase api.EventType = "ma-mpc-DataUpdateCurrentPerPhase"
+
+	// Phase specific voltage details
+	//
+	// Use `VoltagePerPhase` to get the current data
+	//
+	// Use Case MPC, Scenario 4
+	DataUpdateVoltagePerPhase api.EventType = "ma-mpc-DataUpdateVoltagePerPhase"
+
+	// Power network frequency data
+	//
+	// Use `Frequency` to get the current data
+	//
+	// Use Case MPC, Scenario 5
+	DataUpdateFrequency api.EventType = "ma-mpc-DataUpdateFrequency"
+)
@@ -0,0 +1,5 @@
+package mpc
+
+func (s *MaMPCSuite) Test_UpdateUseCaseAvailability() {
+	s.sut.UpdateUseCaseAvailability(true)
+}
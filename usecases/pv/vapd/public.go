@@ -0,0 +1,54 @@
+package vapd
+
+import (
+	"github.com/enbility/eebus-go/features/server"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+)
+
+// Scenario 1
+
+// set the nominal photovoltaic peak power (W)
+func (e *VAPD) SetPowerNominalPeak(power float64) error {
+	dcs, err := server.NewDeviceConfiguration(e.LocalEntity)
+	if err != nil {
+		return err
+	}
+
+	data := model.DeviceConfigurationKeyValueDataType{
+		Value: &model.DeviceConfigurationKeyValueValueType{
+			ScaledNumber: model.NewScaledNumberType(power),
+		},
+		IsValueChangeable: util.Ptr(false),
+	}
+	filter := model.DeviceConfigurationKeyValueDescriptionDataType{
+		KeyName:   util.Ptr(model.DeviceConfigurationKeyNameTypePeakPowerOfPVSystem),
+		ValueType: util.Ptr(model.DeviceConfigurationKeyValueTypeTypeScaledNumber),
+	}
+
+	return dcs.UpdateKeyValueDataForFilter(data, nil, filter)
+}
+
+// Scenario 2
+
+// set the current photovoltaic production power (W)
+func (e *VAPD) SetPower(power float64) error {
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypePower),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeACPowerTotal),
+	}
+	return e.updateMeasurementData(filter, power)
+}
+
+// Scenario 3
+
+// set the total photovoltaic yield (Wh)
+func (e *VAPD) SetPVYieldTotal(yield float64) error {
+	filter := model.MeasurementDescriptionDataType{
+		MeasurementType: util.Ptr(model.MeasurementTypeTypeEnergy),
+		CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+		ScopeType:       util.Ptr(model.ScopeTypeTypeACYieldTotal),
+	}
+	return e.updateMeasurementData(filter, yield)
+}
@@ -0,0 +1,68 @@
+package vapd
+
+import (
+	"github.com/enbility/eebus-go/features/server"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *PvVAPDSuite) measurementValue(scope model.ScopeTypeType) (float64, bool) {
+	measurement, err := server.NewMeasurement(s.sut.LocalEntity)
+	assert.Nil(s.T(), err)
+
+	filter := model.MeasurementDescriptionDataType{
+		ScopeType: util.Ptr(scope),
+	}
+	data, err := measurement.GetDataForFilter(filter)
+	if err != nil || len(data) != 1 || data[0].Value == nil {
+		return 0, false
+	}
+
+	return data[0].Value.GetValue(), true
+}
+
+func (s *PvVAPDSuite) Test_SetPowerNominalPeak() {
+	err := s.sut.SetPowerNominalPeak(10000)
+	assert.Nil(s.T(), err)
+
+	dcs, err := server.NewDeviceConfiguration(s.sut.LocalEntity)
+	assert.Nil(s.T(), err)
+
+	filter := model.DeviceConfigurationKeyValueDescriptionDataType{
+		KeyName: util.Ptr(model.DeviceConfigurationKeyNameTypePeakPowerOfPVSystem),
+	}
+	data, err := dcs.GetKeyValueDataForFilter(filter)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), data)
+	assert.Equal(s.T(), 10000.0, data.Value.ScaledNumber.GetValue())
+
+	s.sut.LocalEntity = nil
+	err = s.sut.SetPowerNominalPeak(10000)
+	assert.NotNil(s.T(), err)
+}
+
+func (s *PvVAPDSuite) Test_SetPower() {
+	_, ok := s.measurementValue(model.ScopeTypeTypeACPowerTotal)
+	assert.False(s.T(), ok)
+
+	err := s.sut.SetPower(5000)
+	assert.Nil(s.T(), err)
+
+	value, ok := s.measurementValue(model.ScopeTypeTypeACPowerTotal)
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), 5000.0, value)
+
+	s.sut.LocalEntity = nil
+	err = s.sut.SetPower(5000)
+	assert.NotNil(s.T(), err)
+}
+
+func (s *PvVAPDSuite) Test_SetPVYieldTotal() {
+	err := s.sut.SetPVYieldTotal(123456)
+	assert.Nil(s.T(), err)
+
+	value, ok := s.measurementValue(model.ScopeTypeTypeACYieldTotal)
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), 123456.0, value)
+}
@@ -0,0 +1,181 @@
+package vapd
+
+import (
+	"time"
+
+	"github.com/enbility/eebus-go/api"
+	"github.com/enbility/eebus-go/features/server"
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/enbility/eebus-go/usecases/usecase"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/spine"
+	"github.com/enbility/spine-go/util"
+)
+
+type VAPD struct {
+	*usecase.UseCaseBase
+}
+
+var _ ucapi.PvVAPDInterface = (*VAPD)(nil)
+
+// Add support for the Visualization of Aggregated Photovoltaic Data (VAPD) use case
+// as a PV System actor
+//
+// Parameters:
+//   - localEntity: The local entity which should support the use case, should be of type PVSystem
+//   - eventCB: The callback to be called when an event is triggered (optional, can be nil)
+func NewVAPD(localEntity spineapi.EntityLocalInterface, eventCB api.EntityEventCallback) *VAPD {
+	validActorTypes := []model.UseCaseActorType{model.UseCaseActorTypeCEM}
+	validEntityTypes := []model.EntityTypeType{model.EntityTypeTypeCEM}
+	useCaseScenarios := []api.UseCaseScenario{
+		{
+			Scenario:  model.UseCaseScenarioSupportType(1),
+			Mandatory: true,
+		},
+		{
+			Scenario:  model.UseCaseScenarioSupportType(2),
+			Mandatory: true,
+		},
+		{
+			Scenario:  model.UseCaseScenarioSupportType(3),
+			Mandatory: true,
+		},
+	}
+
+	usecase := usecase.NewUseCaseBase(
+		localEntity,
+		model.UseCaseActorTypePVSystem,
+		model.UseCaseNameTypeVisualizationOfAggregatedPhotovoltaicData,
+		"1.0.1",
+		"RC1",
+		useCaseScenarios,
+		eventCB,
+		UseCaseSupportUpdate,
+		validActorTypes,
+		validEntityTypes,
+	)
+
+	uc := &VAPD{
+		UseCaseBase: usecase,
+	}
+
+	_ = spine.Events.Subscribe(uc)
+
+	return uc
+}
+
+func (e *VAPD) AddFeatures() {
+	// server features
+	f := e.LocalEntity.GetOrAddFeature(model.FeatureTypeTypeDeviceConfiguration, model.RoleTypeServer)
+	f.AddFunctionType(model.FunctionTypeDeviceConfigurationKeyValueDescriptionListData, true, false)
+	f.AddFunctionType(model.FunctionTypeDeviceConfigurationKeyValueListData, true, false)
+
+	if dcs, err := server.NewDeviceConfiguration(e.LocalEntity); err == nil {
+		// only add if it doesn't exist yet
+		filter := model.DeviceConfigurationKeyValueDescriptionDataType{
+			KeyName: util.Ptr(model.DeviceConfigurationKeyNameTypePeakPowerOfPVSystem),
+		}
+		if data, err := dcs.GetKeyValueDescriptionsForFilter(filter); err != nil || len(data) == 0 {
+			dcs.AddKeyValueDescription(
+				model.DeviceConfigurationKeyValueDescriptionDataType{
+					KeyName:   util.Ptr(model.DeviceConfigurationKeyNameTypePeakPowerOfPVSystem),
+					ValueType: util.Ptr(model.DeviceConfigurationKeyValueTypeTypeScaledNumber),
+					Unit:      util.Ptr(model.UnitOfMeasurementTypeW),
+				},
+			)
+		}
+	}
+
+	f = e.LocalEntity.GetOrAddFeature(model.FeatureTypeTypeElectricalConnection, model.RoleTypeServer)
+	f.AddFunctionType(model.FunctionTypeElectricalConnectionDescriptionListData, true, false)
+	f.AddFunctionType(model.FunctionTypeElectricalConnectionParameterDescriptionListData, true, false)
+
+	f = e.LocalEntity.GetOrAddFeature(model.FeatureTypeTypeMeasurement, model.RoleTypeServer)
+	f.AddFunctionType(model.FunctionTypeMeasurementDescriptionListData, true, false)
+	f.AddFunctionType(model.FunctionTypeMeasurementListData, true, false)
+
+	ec, err := server.NewElectricalConnection(e.LocalEntity)
+	if err != nil {
+		return
+	}
+	measurement, err := server.NewMeasurement(e.LocalEntity)
+	if err != nil {
+		return
+	}
+
+	electricalConnectionId := util.Ptr(model.ElectricalConnectionIdType(0))
+
+	// only add if it doesn't exist yet
+	filter := model.ElectricalConnectionDescriptionDataType{
+		ElectricalConnectionId: electricalConnectionId,
+	}
+	if data, err := ec.GetDescriptionsForFilter(filter); err != nil || len(data) == 0 {
+		_ = ec.AddDescription(model.ElectricalConnectionDescriptionDataType{
+			ElectricalConnectionId:  electricalConnectionId,
+			PowerSupplyType:         util.Ptr(model.ElectricalConnectionVoltageTypeTypeAc),
+			PositiveEnergyDirection: util.Ptr(model.EnergyDirectionTypeProduce),
+		})
+	}
+
+	descriptions := []model.MeasurementDescriptionDataType{
+		{
+			// Scenario 2
+			MeasurementType: util.Ptr(model.MeasurementTypeTypePower),
+			CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+			Unit:            util.Ptr(model.UnitOfMeasurementTypeW),
+			ScopeType:       util.Ptr(model.ScopeTypeTypeACPowerTotal),
+		},
+		{
+			// Scenario 3
+			MeasurementType: util.Ptr(model.MeasurementTypeTypeEnergy),
+			CommodityType:   util.Ptr(model.CommodityTypeTypeElectricity),
+			Unit:            util.Ptr(model.UnitOfMeasurementTypeWh),
+			ScopeType:       util.Ptr(model.ScopeTypeTypeACYieldTotal),
+		},
+	}
+
+	for _, description := range descriptions {
+		// only add if it doesn't exist yet
+		if data, err := measurement.GetDescriptionsForFilter(description); err == nil && len(data) > 0 {
+			continue
+		}
+
+		measurementId := measurement.AddDescription(description)
+		if measurementId == nil {
+			continue
+		}
+
+		_ = ec.AddParameterDescription(model.ElectricalConnectionParameterDescriptionDataType{
+			ElectricalConnectionId: electricalConnectionId,
+			MeasurementId:          measurementId,
+			VoltageType:            util.Ptr(model.ElectricalConnectionVoltageTypeTypeAc),
+			AcMeasuredPhases:       util.Ptr(model.ElectricalConnectionPhaseNameTypeAbc),
+			AcMeasurementType:      util.Ptr(model.ElectricalConnectionAcMeasurementTypeTypeReal),
+			AcMeasurementVariant:   util.Ptr(model.ElectricalConnectionMeasurandVariantTypeRms),
+		})
+	}
+}
+
+// update the measurement data of the single measurement matching the filter
+func (e *VAPD) updateMeasurementData(filter model.MeasurementDescriptionDataType, value float64) error {
+	measurement, err := server.NewMeasurement(e.LocalEntity)
+	if err != nil {
+		return err
+	}
+
+	data := []api.MeasurementDataForFilter{
+		{
+			Data: model.MeasurementDataType{
+				ValueType:   util.Ptr(model.MeasurementValueTypeTypeValue),
+				Timestamp:   model.NewAbsoluteOrRelativeTimeTypeFromTime(time.Now()),
+				Value:       model.NewScaledNumberType(value),
+				ValueSource: util.Ptr(model.MeasurementValueSourceTypeMeasuredValue),
+				ValueState:  util.Ptr(model.MeasurementValueStateTypeNormal),
+			},
+			Filter: filter,
+		},
+	}
+
+	return measurement.UpdateDataForFilters(data, nil, nil)
+}
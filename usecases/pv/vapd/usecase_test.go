@@ -0,0 +1,42 @@
+package vapd
+
+import (
+	"github.com/enbility/eebus-go/features/server"
+	"github.com/enbility/spine-go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *PvVAPDSuite) Test_AddFeatures() {
+	assert.NotNil(s.T(), s.measurementFeature)
+	assert.NotNil(s.T(), s.electricalConnectionFeature)
+	assert.NotNil(s.T(), s.deviceConfigurationFeature)
+
+	measurement, err := server.NewMeasurement(s.sut.LocalEntity)
+	assert.Nil(s.T(), err)
+
+	descs, err := measurement.GetDescriptionsForFilter(model.MeasurementDescriptionDataType{})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 2, len(descs))
+
+	dcs, err := server.NewDeviceConfiguration(s.sut.LocalEntity)
+	assert.Nil(s.T(), err)
+
+	keys, err := dcs.GetKeyValueDescriptionsForFilter(model.DeviceConfigurationKeyValueDescriptionDataType{})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, len(keys))
+
+	// adding the features again must not duplicate the descriptions
+	s.sut.AddFeatures()
+
+	descs, err = measurement.GetDescriptionsForFilter(model.MeasurementDescriptionDataType{})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 2, len(descs))
+
+	keys, err = dcs.GetKeyValueDescriptionsForFilter(model.DeviceConfigurationKeyValueDescriptionDataType{})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, len(keys))
+}
+
+func (s *PvVAPDSuite) Test_UpdateUseCaseAvailability() {
+	s.sut.UpdateUseCaseAvailability(true)
+}